@@ -0,0 +1,69 @@
+package flags_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type withErrors struct {
+	Port  int
+	Ratio float64
+	Base  base
+	Level string `enum:"debug,info"`
+}
+
+func TestAggregate(t *testing.T) {
+	f, err := flags.Parse(strings.Fields("--port x --ratio y --num z --level 'warn'"))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	err = (&flags.Parser{Validate: true}).Insert(f, new(withErrors))
+	for _, part := range []string{"field Port:", "field Ratio:", "field Base.Num:", "field Level:"} {
+		if err == nil || !strings.Contains(err.Error(), part) {
+			t.Fatalf("error %v doesn't contain %q", err, part)
+		}
+	}
+	if !errors.Is(err, flags.CANT_CONVERT()) || !errors.Is(err, flags.NOT_ALLOWED()) {
+		t.Fatalf("got different errors expected %v and %v, got %v", flags.CANT_CONVERT(), flags.NOT_ALLOWED(), err)
+	}
+
+	err = (&flags.Parser{FailFast: true}).Insert(f, new(withErrors))
+	if err == nil || !strings.HasPrefix(err.Error(), "field Port: ") || strings.Contains(err.Error(), "Ratio") {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	err := flags.Load(strings.Fields("--ratio 0.5 --num z --port 1 2"), new(withErrors))
+
+	var conv *flags.ConversionError
+	if !errors.As(err, &conv) {
+		t.Fatalf("got error %v, expected a conversion error", err)
+	}
+	var num *strconv.NumError
+	if conv.Flag != "num" || conv.Field != "Base.Num" || conv.Value != "z" || conv.Position != 3 || !errors.As(conv.Cause, &num) {
+		t.Fatalf("got conversion error %#v", conv)
+	}
+
+	var arity *flags.ArityError
+	if !errors.As(err, &arity) {
+		t.Fatalf("got error %v, expected an arity error", err)
+	}
+	if arity.Flag != "port" || arity.Field != "Port" || arity.Got != 2 || arity.Position != 4 {
+		t.Fatalf("got arity error %#v", arity)
+	}
+	if !errors.Is(err, flags.CANT_CONVERT()) || !errors.Is(err, flags.TOO_MANY_ARGUMENTS()) {
+		t.Fatalf("got error %v", err)
+	}
+
+	_, err = flags.ParseWithShortcuts(strings.Fields("--port 1 -px"), map[rune]string{'p': "port"})
+	var short *flags.UnknownShortcutError
+	if !errors.As(err, &short) || short.Shortcut != 'x' || short.Position != 2 || short.Offset != 2 || !errors.Is(err, flags.WRONG_SHORTCUT()) {
+		t.Fatalf("got error %v", err)
+	}
+}
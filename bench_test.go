@@ -0,0 +1,69 @@
+package flags_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type benchQuery struct {
+	Page     int
+	PerPage  int
+	Sort     string
+	Desc     bool
+	Tags     []string
+	UserID   uint
+	MaxPrice float64
+	Filter   struct {
+		Name  string
+		Owner string
+	}
+}
+
+// The flags are the same for the first version of Insert, so the results
+// could be compared.
+var benchArgs = strings.Fields("--page 2 --per_page 50 --sort 'name' --desc --tags 'a' 'b' --user_i_d 7 --max_price 9.5 --name 'x'")
+
+func benchmarkInsert(b *testing.B, p *flags.Parser) {
+	f, err := flags.Parse(benchArgs)
+	if err != nil {
+		b.Fatalf("got an error: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := p.Insert(f, new(benchQuery)); err != nil {
+			b.Fatalf("got an error: %v", err)
+		}
+	}
+}
+
+// The fields of the struct are cached after the first call.
+func BenchmarkInsert(b *testing.B) {
+	benchmarkInsert(b, new(flags.Parser))
+}
+
+// The fields of the struct are built on every call, because a custom naming
+// strategy isn't cached. It is the same strategy as the default one, so the
+// work is the same as in [BenchmarkInsert] but the cache.
+func BenchmarkInsertUncached(b *testing.B) {
+	benchmarkInsert(b, &flags.Parser{Naming: func(field string) string { return flags.LegacySnakeCase(field) }})
+}
+
+func BenchmarkInsertParallel(b *testing.B) {
+	f, err := flags.Parse(benchArgs)
+	if err != nil {
+		b.Fatalf("got an error: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if err := flags.Insert(f, new(benchQuery)); err != nil {
+				b.Fatalf("got an error: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,317 @@
+// Package bind inserts flags into struct fields without reflection, it is
+// used by code generated with the flagsgen command.
+//
+// Values are converted with the same rules as [flags.Insert] uses and errors
+// are the same types ([flags.ConversionError], [flags.ArityError],
+// [flags.NotAllowedError] and [flags.FieldError]), so they work with
+// [errors.Is] and [errors.As] the same way. The package doesn't import
+// reflect (and packages, that use it).
+//
+// Example, for field `Port int`:
+//
+//	func (v *Config) BindFlags(b *bind.Binder) error {
+//		bind.Value(b, bind.Field{Flag: "port", Path: "Port", Type: "int"}, &v.Port, bind.ConvertInt[int])
+//		return b.Err()
+//	}
+package bind
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/vandi37/flags/internal/convert"
+)
+
+// it inserts parsed flags into fields, errors of all fields are returned
+// together by [Binder.Err].
+//
+// Environment variables are used for flags, that aren't set, the same way
+// as [flags.Parser.Insert] does.
+type Binder struct {
+	// If it isn't empty, every field without an `env` tag is also looked up in
+	// the environment as the upper cased flag name with this prefix (see
+	// [flags.Parser.EnvPrefix]).
+	EnvPrefix string
+
+	// It is used to look up environment variables, if it is nil [os.LookupEnv] is used.
+	LookupEnv func(key string) (string, bool)
+
+	// It splits environment values for slices, if it is empty "," is used.
+	EnvSeparator string
+
+	// If it is true, values of string fields don't need brackets (see
+	// [flags.Parser.UnquotedStrings]).
+	UnquotedStrings bool
+
+	// If it is true, the `required` and `enum` tags are checked (see
+	// [flags.Parser.Validate]).
+	Validate bool
+
+	// The width of help text (see [Binder.FlagUsage]), if it is zero, the
+	// COLUMNS environment variable or 80 is used.
+	Width int
+
+	flags map[string][]string
+	errs  []error
+}
+
+// it describes a field for [Binder].
+type Field struct {
+	// The full flag name.
+	Flag string
+	// The path of the field, nested struct fields are separated by dots (e.g. "Db.Host").
+	Path string
+	// The type name for errors (see [flags.ConversionError]).
+	Type string
+	// The `env` tag, "-" disables the environment variable. If it is empty,
+	// the variable is built from [Binder.EnvPrefix].
+	Env string
+	// It is true for strings, values of the environment variable and, with
+	// [Binder.UnquotedStrings], unquoted values of the flag are quoted.
+	Quote bool
+	// It is true for fields with the `required:"true"` tag.
+	Required bool
+	// The values from the `enum` tag.
+	Enum []string
+}
+
+// it converts a single value from the command line, the type name is used in errors.
+type Converter[T any] func(arg string, typ string) (T, error)
+
+// The types for converters.
+type (
+	Signed interface {
+		~int | ~int8 | ~int16 | ~int32 | ~int64
+	}
+	Unsigned interface {
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+	}
+	Float interface {
+		~float32 | ~float64
+	}
+	Complex interface {
+		~complex64 | ~complex128
+	}
+)
+
+// it returns a new binder for parsed flags (see [flags.Parse]).
+func New(flags map[string][]string) *Binder {
+	return &Binder{flags: flags}
+}
+
+// it reports whether the flag or the environment variable of the field has
+// values to insert.
+func (b *Binder) Has(f Field) bool {
+	_, ok := b.values(f, false)
+	return ok
+}
+
+// it returns all errors of fields, or nil.
+func (b *Binder) Err() error {
+	if len(b.errs) > 0 {
+		return convert.Join("got some errors", b.errs)
+	}
+	return nil
+}
+
+// it returns values of the flag or of the environment variable, the value of
+// the environment variable is split for slices.
+func (b *Binder) values(f Field, slice bool) ([]string, bool) {
+	if args, ok := b.flags[f.Flag]; ok {
+		if b.UnquotedStrings && f.Quote && args != nil {
+			res := make([]string, len(args))
+			for i, arg := range args {
+				if _, ok := convert.String(arg); !ok {
+					arg = convert.Quote(arg)
+				}
+				res[i] = arg
+			}
+			args = res
+		}
+		return args, args != nil
+	}
+	name := convert.EnvVariable(f.Env, b.EnvPrefix, f.Flag)
+	if name == "" {
+		return nil, false
+	}
+
+	val, ok := b.lookupEnv(name)
+	if !ok || val == "" {
+		return nil, false
+	}
+
+	args := []string{val}
+	if slice {
+		sep := b.EnvSeparator
+		if sep == "" {
+			sep = ","
+		}
+		args = strings.Split(val, sep)
+	}
+	if f.Quote {
+		for i, arg := range args {
+			args[i] = convert.Quote(arg)
+		}
+	}
+	return args, true
+}
+
+func (b *Binder) lookupEnv(key string) (string, bool) {
+	if b.LookupEnv != nil {
+		return b.LookupEnv(key)
+	}
+	return os.LookupEnv(key)
+}
+
+// it returns values of the field, it reports false if the field isn't set.
+func (b *Binder) args(f Field, slice bool) ([]string, bool) {
+	args, ok := b.values(f, slice)
+	if !b.Validate {
+		return args, ok
+	}
+	if !ok {
+		if f.Required {
+			b.fail(f, convert.Required(f.Flag))
+		}
+		return nil, false
+	}
+
+	if err := convert.CheckEnum(f.Enum, args, f.Flag); err != nil {
+		b.fail(f, err)
+		return nil, false
+	}
+	return args, true
+}
+
+// it adds the error with the flag and the path of the field.
+func (b *Binder) fail(f Field, err error) {
+	var conv *convert.ConversionError
+	var arity *convert.ArityError
+	var enum *convert.NotAllowedError
+	switch {
+	case errors.As(err, &conv):
+		conv.Flag, conv.Field = f.Flag, f.Path
+	case errors.As(err, &enum):
+		enum.Field = f.Path
+	case errors.As(err, &arity):
+		arity.Flag, arity.Field = f.Flag, f.Path
+	default:
+		err = &convert.FieldError{Flag: f.Flag, Field: f.Path, Position: -1, Err: err}
+	}
+	b.errs = append(b.errs, err)
+}
+
+// it inserts a single value into the field.
+func Value[T any](b *Binder, f Field, dst *T, conv Converter[T]) {
+	args, ok := b.args(f, false)
+	if !ok {
+		return
+	}
+	if len(args) != 1 {
+		b.fail(f, convert.Arity(f.Flag, len(args), 1))
+		return
+	}
+
+	val, err := conv(args[0], f.Type)
+	if err != nil {
+		b.fail(f, err)
+		return
+	}
+	*dst = val
+}
+
+// it inserts a bool into the field, the flag without values is true.
+func BoolValue[T ~bool](b *Binder, f Field, dst *T) {
+	if args, ok := b.flags[f.Flag]; ok && args != nil && len(args) == 0 {
+		*dst = true
+		return
+	}
+	Value(b, f, dst, ConvertBool[T])
+}
+
+// it appends all values to the slice.
+func Slice[T any](b *Binder, f Field, dst *[]T, conv Converter[T]) {
+	args, ok := b.args(f, true)
+	if !ok {
+		return
+	}
+
+	// The slice is extended first, so values after an invalid one are zero,
+	// the same way as [flags.Insert] does.
+	n := len(*dst)
+	*dst = append(*dst, make([]T, len(args))...)
+	for i, arg := range args {
+		val, err := conv(arg, f.Type)
+		if err != nil {
+			b.fail(f, err)
+			return
+		}
+		(*dst)[n+i] = val
+	}
+}
+
+// it inserts a value into the pointer field with the bind function, the
+// pointer is allocated if the field is set.
+func Pointer[T any](b *Binder, f Field, dst **T, bind func(b *Binder, f Field, dst *T)) {
+	if !b.Has(f) {
+		b.args(f, false)
+		return
+	}
+	if *dst == nil {
+		*dst = new(T)
+	}
+	bind(b, f, *dst)
+}
+
+// it converts a bool.
+func ConvertBool[T ~bool](arg string, typ string) (T, error) {
+	v, err := convert.BoolArg(arg)
+	return T(v), err
+}
+
+// it converts a signed integer, bases from 2 to 16 and durations are allowed.
+func ConvertInt[T Signed](arg string, typ string) (T, error) {
+	var zero T
+	v, err := convert.IntArg(arg, sizeOf(zero), typ)
+	return T(v), err
+}
+
+// it converts an unsigned integer, bases from 2 to 16 are allowed.
+func ConvertUint[T Unsigned](arg string, typ string) (T, error) {
+	var zero T
+	v, err := convert.UintArg(arg, sizeOf(zero), typ)
+	return T(v), err
+}
+
+// it converts a float.
+func ConvertFloat[T Float](arg string, typ string) (T, error) {
+	var zero T
+	v, err := convert.FloatArg(arg, sizeOf(zero))
+	return T(v), err
+}
+
+// it converts a complex number.
+func ConvertComplex[T Complex](arg string, typ string) (T, error) {
+	var zero T
+	v, err := convert.ComplexArg(arg, sizeOf(zero))
+	return T(v), err
+}
+
+// it converts a string, the value has to be in brackets.
+func ConvertString[T ~string](arg string, typ string) (T, error) {
+	v, err := convert.StringArg(arg)
+	return T(v), err
+}
+
+// it converts a time with the time formats (see [flags.GetTimeFormats]).
+func ConvertTime(arg string, typ string) (time.Time, error) {
+	return convert.TimeArg(arg)
+}
+
+// it returns the size of the type in bits.
+func sizeOf[T any](v T) int {
+	return int(unsafe.Sizeof(v)) * 8
+}
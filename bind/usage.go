@@ -0,0 +1,101 @@
+package bind
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vandi37/flags/internal/convert"
+	"github.com/vandi37/flags/internal/usage"
+)
+
+// it describes a flag for help text, it is the same type as [flags.FlagInfo].
+type FlagInfo = usage.Flag
+
+// it returns help text for the flags the same way as
+// [flags.Parser.FlagUsage], environment variables are built from
+// [Binder.EnvPrefix].
+func (b *Binder) FlagUsage(flags []FlagInfo) string {
+	infos := []FlagInfo{}
+	for _, info := range flags {
+		info.Env = convert.EnvVariable(info.Env, b.EnvPrefix, info.Name)
+		infos = append(infos, info)
+	}
+
+	var res strings.Builder
+	usage.Write(&res, infos, usage.Width(b.Width, b.lookupEnv))
+	return res.String()
+}
+
+// The formatting of default values for help text, it is the same as
+// [flags.Usage] uses for the current values of fields.
+
+// it returns the formatted value, or an empty string for the zero value.
+func Default[T comparable](v T, format func(T) string) string {
+	var zero T
+	if v == zero {
+		return ""
+	}
+	return format(v)
+}
+
+// it returns the formatted value of the pointer, or an empty string for nil
+// and the zero value.
+func DefaultPointer[T comparable](v *T, format func(T) string) string {
+	if v == nil {
+		return ""
+	}
+	return Default(*v, format)
+}
+
+// it returns the formatted values separated by spaces, or an empty string for nil.
+func DefaultSlice[T any](v []T, format func(T) string) string {
+	vals := []string{}
+	for _, val := range v {
+		vals = append(vals, format(val))
+	}
+	return strings.Join(vals, " ")
+}
+
+func FormatBool[T ~bool](v T) string {
+	return strconv.FormatBool(bool(v))
+}
+
+func FormatInt[T Signed](v T) string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+func FormatUint[T Unsigned](v T) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func FormatFloat[T Float](v T) string {
+	var zero T
+	return strconv.FormatFloat(float64(v), 'g', -1, sizeOf(zero))
+}
+
+func FormatComplex[T Complex](v T) string {
+	var zero T
+	return strconv.FormatComplex(complex128(v), 'g', -1, sizeOf(zero))
+}
+
+// it returns the string in brackets, the empty string isn't quoted.
+func FormatString[T ~string](v T) string {
+	if v == "" {
+		return ""
+	}
+	return "'" + string(v) + "'"
+}
+
+// it formats a value with the String method (e.g. a named integer type).
+func FormatStringer[T interface{ String() string }](v T) string {
+	return v.String()
+}
+
+func FormatDuration(v time.Duration) string {
+	return v.String()
+}
+
+func FormatTime(v time.Time) string {
+	return v.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,568 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vandi37/flags"
+	"github.com/vandi37/flags/internal/tags"
+)
+
+// The kinds of leaf fields.
+const (
+	kindBool = iota
+	kindInt
+	kindUint
+	kindFloat
+	kindComplex
+	kindString
+	kindTime
+)
+
+// The converters for kinds (see [bind.Converter]).
+var converters = map[int]string{
+	kindBool:    "bind.ConvertBool",
+	kindInt:     "bind.ConvertInt",
+	kindUint:    "bind.ConvertUint",
+	kindFloat:   "bind.ConvertFloat",
+	kindComplex: "bind.ConvertComplex",
+	kindString:  "bind.ConvertString",
+	kindTime:    "bind.ConvertTime",
+}
+
+// The formatters of default values for kinds.
+var formatters = map[int]string{
+	kindBool:    "bind.FormatBool",
+	kindInt:     "bind.FormatInt",
+	kindUint:    "bind.FormatUint",
+	kindFloat:   "bind.FormatFloat",
+	kindComplex: "bind.FormatComplex",
+	kindString:  "bind.FormatString",
+}
+
+// it is the type of a field.
+type fieldType struct {
+	// The type as it is written in the source.
+	expr string
+	kind int
+	// The name of the kind (e.g. "int" or "string").
+	kindName string
+	// The type name for errors (see [flags.ConversionError]).
+	errName string
+	// The type name for help text.
+	usageName string
+
+	slice   bool
+	pointer bool
+	// The struct for nested structs, it is nil for other fields.
+	nested *ast.StructType
+}
+
+// it holds the state of the generation.
+type generator struct {
+	fset  *token.FileSet
+	pkg   string
+	types map[string]ast.Expr
+	// Types with the FlagName method (see [flags.Namer]).
+	namers map[string]bool
+	// Types with the String method of a value receiver (see [bind.FormatStringer]).
+	stringers map[string]bool
+
+	insert bytes.Buffer
+	infos  []flags.FlagInfo
+	// The expressions of default values of infos.
+	defaults []string
+	// The shortcuts and aliases of flags.
+	tags []tags.Field
+}
+
+// it generates the methods of the struct (see the package documentation).
+func generate(dir string, typeName string, naming flags.NameFunc) ([]byte, error) {
+	g := &generator{
+		fset:      token.NewFileSet(),
+		types:     map[string]ast.Expr{},
+		namers:    map[string]bool{},
+		stringers: map[string]bool{},
+	}
+	if err := g.load(dir); err != nil {
+		return nil, err
+	}
+
+	expr, ok := g.types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("flagsgen: type %s isn't found in %s", typeName, dir)
+	}
+	st, ok := expr.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("flagsgen: type %s isn't a struct", typeName)
+	}
+
+	naming, err := g.naming(typeName, "", naming)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.fields(st, scope{value: "v", naming: naming}); err != nil {
+		return nil, err
+	}
+	// Conflicts are checked the same way as [flags.TagShortcuts] and
+	// [flags.TagAliases] do.
+	errs := []error{}
+	tags.ShortcutMap(g.tags, func(s rune, first string, second string) {
+		errs = append(errs, flags.SHORTCUT_CONFLICT(s, first, second))
+	})
+	tags.AliasMap(g.tags, func(alias string, first string, second string) {
+		errs = append(errs, flags.ALIAS_CONFLICT(alias, first, second))
+	})
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	return g.source(typeName)
+}
+
+// it reads type declarations of the package.
+func (g *generator) load(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return fmt.Errorf("flagsgen: %w", err)
+	}
+
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "_flags.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("flagsgen: %w", err)
+		}
+		file, err := parser.ParseFile(g.fset, name, src, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("flagsgen: %w", err)
+		}
+
+		g.pkg = file.Name.Name
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok && spec.Assign == 0 {
+						g.types[spec.Name.Name] = spec.Type
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv == nil || len(decl.Recv.List) != 1 {
+					continue
+				}
+				recv := g.print(decl.Recv.List[0].Type)
+				switch decl.Name.Name {
+				case "FlagName":
+					g.namers[strings.TrimPrefix(recv, "*")] = true
+				case "String":
+					if !strings.HasPrefix(recv, "*") && decl.Type.Params.NumFields() == 0 && decl.Type.Results.NumFields() == 1 && g.print(decl.Type.Results.List[0].Type) == "string" {
+						g.stringers[recv] = true
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// it returns the naming strategy for fields of a struct (see [flags.Namer]).
+func (g *generator) naming(typeName string, tag string, naming flags.NameFunc) (flags.NameFunc, error) {
+	if f, ok := flags.TagNaming(tag); ok {
+		return f, nil
+	}
+	if g.namers[typeName] {
+		return nil, fmt.Errorf("flagsgen: type %s implements flags.Namer, it isn't supported", typeName)
+	}
+	return naming, nil
+}
+
+// it is the struct, that fields are generated for.
+type scope struct {
+	// The expression of the struct (e.g. "v.Db").
+	value string
+	// The prefix of flag names.
+	prefix string
+	// The path of fields.
+	path string
+	// The path of the struct for help text.
+	group  string
+	naming flags.NameFunc
+	// The pointers to nested structs on the way to the struct, they may be nil
+	// for help text.
+	pointers []string
+}
+
+// it generates code for fields of a struct.
+func (g *generator) fields(st *ast.StructType, sc scope) error {
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			s, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return fmt.Errorf("flagsgen: %w", err)
+			}
+			tag = reflect.StructTag(s)
+		}
+
+		names := []string{}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		if len(names) == 0 {
+			names = append(names, strings.TrimPrefix(strings.TrimPrefix(g.print(field.Type), "*"), g.pkg+"."))
+		}
+
+		for _, name := range names {
+			if !ast.IsExported(name) {
+				continue
+			}
+			if err := g.field(field.Type, tag, name, sc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) field(expr ast.Expr, tag reflect.StructTag, name string, sc scope) error {
+	flagName, _, _ := strings.Cut(tag.Get("flag"), ",")
+	if flagName == "-" {
+		return nil
+	}
+	if flagName == "" {
+		flagName = sc.naming(name)
+	}
+
+	fieldPath := sc.path + name
+	fieldValue := sc.value + "." + name
+	t, err := g.fieldType(expr, fieldPath)
+	if err != nil {
+		return err
+	}
+
+	if t.nested != nil {
+		nested := scope{
+			value:    fieldValue,
+			prefix:   sc.prefix,
+			path:     fieldPath + ".",
+			group:    fieldPath,
+			pointers: sc.pointers,
+		}
+		if p := tag.Get("prefix"); p != "" {
+			nested.prefix += p + "."
+		}
+		if t.pointer {
+			nested.pointers = append(slices.Clip(sc.pointers), fieldValue)
+		}
+		var err error
+		if nested.naming, err = g.naming(strings.TrimPrefix(t.expr, "*"), tag.Get("naming"), sc.naming); err != nil {
+			return err
+		}
+
+		// The pointer is allocated only for structs with flags, the same way
+		// as [flags.Insert] does.
+		start, count := g.insert.Len(), len(g.infos)
+		if t.pointer {
+			fmt.Fprintf(&g.insert, "if %s == nil {\n%s = new(%s)\n}\n", fieldValue, fieldValue, strings.TrimPrefix(t.expr, "*"))
+		}
+		if err := g.fields(t.nested, nested); err != nil {
+			return err
+		}
+		if len(g.infos) == count {
+			g.insert.Truncate(start)
+		}
+		return nil
+	}
+
+	flagName = sc.prefix + flagName
+	required, _ := strconv.ParseBool(tag.Get("required"))
+	enum := []string(nil)
+	if e := tag.Get("enum"); e != "" {
+		enum = strings.Split(e, ",")
+	}
+
+	env := tag.Get("env")
+	field := fmt.Sprintf("bind.Field{Flag: %q, Path: %q, Type: %q", flagName, fieldPath, t.errName)
+	if env != "" {
+		field += fmt.Sprintf(", Env: %q", env)
+	}
+	if t.kind == kindString {
+		field += ", Quote: true"
+	}
+	if required {
+		field += ", Required: true"
+	}
+	if enum != nil {
+		field += fmt.Sprintf(", Enum: %#v", enum)
+	}
+	field += "}"
+
+	elem := strings.TrimPrefix(strings.TrimPrefix(t.expr, "*"), "[]")
+	conv := converters[t.kind]
+	if t.kind != kindTime {
+		conv += "[" + elem + "]"
+	}
+
+	switch {
+	case t.slice:
+		fmt.Fprintf(&g.insert, "bind.Slice(b, %s, &%s, %s)\n", field, fieldValue, conv)
+	case t.pointer:
+		value := fmt.Sprintf("bind.Value(b, f, dst, %s)", conv)
+		if t.kind == kindBool {
+			value = "bind.BoolValue(b, f, dst)"
+		}
+		fmt.Fprintf(&g.insert, "bind.Pointer(b, %s, &%s, func(b *bind.Binder, f bind.Field, dst *%s) {\n%s\n})\n", field, fieldValue, elem, value)
+	case t.kind == kindBool:
+		fmt.Fprintf(&g.insert, "bind.BoolValue(b, %s, &%s)\n", field, fieldValue)
+	default:
+		fmt.Fprintf(&g.insert, "bind.Value(b, %s, &%s, %s)\n", field, fieldValue, conv)
+	}
+
+	info := flags.FlagInfo{
+		Name:     flagName,
+		Group:    sc.group,
+		Type:     t.usageName,
+		Usage:    tag.Get("usage"),
+		Env:      env,
+		Enum:     enum,
+		Required: required,
+	}
+	shortcuts, bad := tags.Shortcuts(tag)
+	if bad != "" {
+		return flags.BAD_SHORTCUT(bad, fieldPath)
+	}
+	if len(shortcuts) > 0 {
+		info.Short = slices.Min(shortcuts)
+	}
+	if aliases := tags.Aliases(tag, sc.prefix); len(aliases) > 0 {
+		info.Aliases = slices.Sorted(slices.Values(aliases))
+	}
+	g.tags = append(g.tags, tags.Field{Flag: flagName, Shortcuts: shortcuts, Aliases: info.Aliases})
+	g.infos = append(g.infos, info)
+	g.defaults = append(g.defaults, g.defaultValue(t, elem, fieldValue, sc.pointers))
+
+	return nil
+}
+
+// it returns the expression of the default value of a field for help text,
+// the same way as [flags.Usage] formats current values of fields.
+func (g *generator) defaultValue(t fieldType, elem string, value string, pointers []string) string {
+	format := ""
+	switch {
+	case t.kind == kindTime:
+		format = "bind.FormatTime"
+	case t.errName == "time.Duration":
+		format = "bind.FormatDuration"
+	case g.stringers[elem] && t.kind != kindString:
+		format = "bind.FormatStringer[" + elem + "]"
+	default:
+		format = formatters[t.kind] + "[" + elem + "]"
+	}
+
+	res := fmt.Sprintf("bind.Default(%s, %s)", value, format)
+	if t.slice {
+		res = fmt.Sprintf("bind.DefaultSlice(%s, %s)", value, format)
+	} else if t.pointer {
+		res = fmt.Sprintf("bind.DefaultPointer(%s, %s)", value, format)
+	}
+
+	// Fields of nil nested structs don't have default values.
+	if len(pointers) > 0 {
+		nils := []string{}
+		for _, p := range pointers {
+			nils = append(nils, p+" == nil")
+		}
+		res = fmt.Sprintf("func() string {\nif %s {\nreturn \"\"\n}\nreturn %s\n}()", strings.Join(nils, " || "), res)
+	}
+	return res
+}
+
+// it returns the type of a field.
+func (g *generator) fieldType(expr ast.Expr, path string) (fieldType, error) {
+	t := fieldType{expr: g.print(expr)}
+	unsupported := fmt.Errorf("flagsgen: type %s of field %s isn't supported", t.expr, path)
+
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		if _, ok := e.X.(*ast.StarExpr); ok {
+			return t, unsupported
+		}
+		inner, err := g.fieldType(e.X, path)
+		if err != nil || inner.slice || inner.pointer || inner.kind == kindTime {
+			return t, unsupported
+		}
+		inner.expr = t.expr
+		inner.pointer = true
+		return inner, nil
+	case *ast.ArrayType:
+		if e.Len != nil {
+			return t, unsupported
+		}
+		inner, err := g.fieldType(e.Elt, path)
+		if err != nil || inner.slice || inner.pointer || inner.nested != nil || inner.kind == kindTime {
+			return t, unsupported
+		}
+		inner.expr = t.expr
+		inner.slice = true
+		inner.usageName = inner.usageName + "..."
+		if inner.kind == kindBool {
+			inner.usageName = "bool..."
+		}
+		return inner, nil
+	case *ast.StructType:
+		t.nested = e
+		return t, nil
+	case *ast.SelectorExpr:
+		switch t.expr {
+		case "time.Duration":
+			t.kind, t.kindName, t.errName, t.usageName = kindInt, "int64", t.expr, "duration"
+			return t, nil
+		case "time.Time":
+			t.kind, t.kindName, t.errName, t.usageName = kindTime, "struct", t.expr, "time"
+			return t, nil
+		}
+		return t, unsupported
+	case *ast.Ident:
+		if kind, ok := basicKind(e.Name); ok && g.types[e.Name] == nil {
+			t.kind, t.kindName = kind, e.Name
+		} else if decl, ok := g.types[e.Name]; ok {
+			if st, ok := decl.(*ast.StructType); ok {
+				t.nested = st
+				return t, nil
+			}
+			inner, err := g.fieldType(decl, path)
+			if err != nil || inner.slice || inner.pointer || inner.nested != nil || inner.kind == kindTime || inner.usageName == "duration" {
+				return t, unsupported
+			}
+			t.kind, t.kindName = inner.kind, inner.kindName
+		} else {
+			return t, unsupported
+		}
+
+		t.errName = t.kindName
+		if t.kind == kindInt || t.kind == kindUint {
+			t.errName = t.expr
+			if _, ok := g.types[e.Name]; ok {
+				t.errName = g.pkg + "." + t.expr
+			}
+		}
+		t.usageName = t.kindName
+		if t.kind == kindBool {
+			t.usageName = ""
+		}
+		return t, nil
+	}
+
+	return t, unsupported
+}
+
+// it returns the kind of a predeclared type.
+func basicKind(name string) (int, bool) {
+	switch name {
+	case "bool":
+		return kindBool, true
+	case "int", "int8", "int16", "int32", "int64":
+		return kindInt, true
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return kindUint, true
+	case "float32", "float64":
+		return kindFloat, true
+	case "complex64", "complex128":
+		return kindComplex, true
+	case "string":
+		return kindString, true
+	}
+	return 0, false
+}
+
+func (g *generator) print(expr ast.Expr) string {
+	var b bytes.Buffer
+	printer.Fprint(&b, g.fset, expr)
+	return b.String()
+}
+
+// it returns the formatted source of the generated file.
+func (g *generator) source(typeName string) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by flagsgen -type %s; DO NOT EDIT.\n\n", typeName)
+	fmt.Fprintf(&b, "package %s\n\n", g.pkg)
+
+	b.WriteString("import (\n")
+	if bytes.Contains(g.insert.Bytes(), []byte("time.")) || slices.ContainsFunc(g.defaults, func(d string) bool { return strings.Contains(d, "time.") }) {
+		b.WriteString("\"time\"\n\n")
+	}
+	b.WriteString("\"github.com/vandi37/flags/bind\"\n)\n\n")
+
+	b.WriteString("// it inserts flags into the struct without reflection, it works the same\n")
+	b.WriteString("// way as flags.Insert (see BindFlags).\n")
+	fmt.Fprintf(&b, "func (v *%s) InsertFlags(f map[string][]string) error {\n", typeName)
+	b.WriteString("return v.BindFlags(bind.New(f))\n}\n\n")
+
+	b.WriteString("// it inserts flags of the binder into the struct without reflection, it\n")
+	b.WriteString("// works the same way as flags.Parser.Insert with the settings of the binder.\n")
+	fmt.Fprintf(&b, "func (v *%s) BindFlags(b *bind.Binder) error {\n", typeName)
+	b.Write(g.insert.Bytes())
+	b.WriteString("return b.Err()\n}\n\n")
+
+	b.WriteString("// it returns help text for the flags of the struct without reflection, it\n")
+	b.WriteString("// works the same way as flags.Usage (see FlagUsageWith).\n")
+	fmt.Fprintf(&b, "func (v *%s) FlagUsage() string {\n", typeName)
+	b.WriteString("return v.FlagUsageWith(bind.New(nil))\n}\n\n")
+
+	b.WriteString("// it returns help text for the flags of the struct without reflection, it\n")
+	b.WriteString("// works the same way as flags.Parser.Usage with the settings of the binder.\n")
+	fmt.Fprintf(&b, "func (v *%s) FlagUsageWith(b *bind.Binder) string {\n", typeName)
+	b.WriteString("return b.FlagUsage([]bind.FlagInfo{\n")
+	for i, info := range g.infos {
+		b.WriteString("{")
+		fmt.Fprintf(&b, "Name: %q", info.Name)
+		if info.Group != "" {
+			fmt.Fprintf(&b, ", Group: %q", info.Group)
+		}
+		if info.Short != 0 {
+			fmt.Fprintf(&b, ", Short: %q", info.Short)
+		}
+		if info.Aliases != nil {
+			fmt.Fprintf(&b, ", Aliases: %#v", info.Aliases)
+		}
+		if info.Type != "" {
+			fmt.Fprintf(&b, ", Type: %q", info.Type)
+		}
+		fmt.Fprintf(&b, ", Default: %s", g.defaults[i])
+		if info.Usage != "" {
+			fmt.Fprintf(&b, ", Usage: %q", info.Usage)
+		}
+		if info.Env != "" {
+			fmt.Fprintf(&b, ", Env: %q", info.Env)
+		}
+		if info.Enum != nil {
+			fmt.Fprintf(&b, ", Enum: %#v", info.Enum)
+		}
+		if info.Required {
+			b.WriteString(", Required: true")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("})\n}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("flagsgen: %w", err)
+	}
+	return src, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	src, err := generate(dir, "Config", flags.LegacySnakeCase)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	need, err := os.ReadFile(filepath.Join(dir, "config_flags.go"))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if !bytes.Equal(src, need) {
+		t.Fatalf("generated code is outdated, run go generate ./internal/gentest")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := map[string]string{
+		"Missing":  "type Other struct{}",
+		"Scalar":   "type Scalar int",
+		"Map":      "type Map struct { Values map[string]int }",
+		"Any":      "type Any struct { Value any }",
+		"Conflict": "type Conflict struct { A int `short:\"a\"`; B int `alias:\"a\"` }",
+		"Named":    "type Named struct{}\nfunc (*Named) FlagName(field string) string { return field }",
+	}
+
+	for name, decl := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte("package types\n\n"+decl+"\n"), 0o644); err != nil {
+				t.Fatalf("got an error: %v", err)
+			}
+			if _, err := generate(dir, name, flags.LegacySnakeCase); err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
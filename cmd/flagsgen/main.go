@@ -0,0 +1,74 @@
+// Flagsgen generates code, that inserts flags into a struct without
+// reflection.
+//
+// It reads the struct from the Go files of a package and writes four
+// methods of the struct:
+//
+//	func (v *Config) InsertFlags(f map[string][]string) error
+//	func (v *Config) BindFlags(b *bind.Binder) error
+//	func (v *Config) FlagUsage() string
+//	func (v *Config) FlagUsageWith(b *bind.Binder) string
+//
+// InsertFlags works the same way as flags.Insert, BindFlags works the same
+// way as flags.Parser.Insert with the settings of the binder (e.g.
+// bind.Binder.EnvPrefix and bind.Binder.Validate), FlagUsage and
+// FlagUsageWith work the same way as flags.Usage and flags.Parser.Usage, but
+// they don't use reflection. The generated code imports only the
+// github.com/vandi37/flags/bind package. Like Insert, BindFlags doesn't read
+// config files (see flags.Parser.ConfigFlag), they are read by flags.Parser.Load.
+//
+// Usage:
+//
+//	//go:generate go run github.com/vandi37/flags/cmd/flagsgen -type Config
+//
+// Flags:
+//
+//	-type string     The name of the struct (required).
+//	-dir string      The directory of the package (default ".").
+//	-output string   The output file, it is <type>_flags.go in the directory by default.
+//	-naming string   The naming strategy: legacy, snake, kebab, dot, lowerCamel or exact (default legacy).
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vandi37/flags"
+)
+
+type options struct {
+	Type   string `usage:"The name of the struct." required:"true"`
+	Dir    string `usage:"The directory of the package."`
+	Output string `usage:"The output file, it is <type>_flags.go in the directory by default."`
+	Naming string `usage:"The naming strategy: legacy, snake, kebab, dot, lowerCamel or exact." enum:"legacy,snake,kebab,dot,lowerCamel,exact"`
+}
+
+func main() {
+	opts := &options{Dir: "."}
+	r := &flags.Runner{
+		Parser: &flags.Parser{SingleDash: flags.SingleDashNames, UnquotedStrings: true, Validate: true},
+		Args:   os.Args[1:],
+	}
+	r.Run(opts, func() error {
+		naming := flags.LegacySnakeCase
+		if opts.Naming != "" {
+			naming, _ = flags.TagNaming(opts.Naming)
+		}
+
+		src, err := generate(opts.Dir, opts.Type, naming)
+		if err != nil {
+			return err
+		}
+
+		output := opts.Output
+		if output == "" {
+			output = filepath.Join(opts.Dir, strings.ToLower(opts.Type)+"_flags.go")
+		}
+		if err := os.WriteFile(output, src, 0o644); err != nil {
+			return fmt.Errorf("flagsgen: %w", err)
+		}
+		return nil
+	})
+}
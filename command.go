@@ -0,0 +1,453 @@
+package flags
+
+import (
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// it is a command of a program with its own flags and subcommands.
+//
+// Example, commands `app serve` and `app migrate up`:
+//
+//	app := &flags.Command{
+//		Name:    "app",
+//		Options: global,
+//		Commands: []*flags.Command{
+//			{Name: "serve", Options: serve, Run: runServe},
+//			{Name: "migrate", Commands: []*flags.Command{
+//				{Name: "up", Options: up, Run: runUp},
+//			}},
+//		},
+//	}
+//	err := app.Execute(os.Args[1:])
+//
+// Flags of a command are inserted into the options of the command and of all
+// its parent commands, so parent options work as global flags. Global flags
+// could be written before the subcommand (e.g. `app -v serve --port 80`).
+type Command struct {
+	// The name of the command, it is used in the command line.
+	Name string
+	// Other names of the command.
+	Aliases []string
+	// A short description for help text and documentation.
+	Description string
+	// A pointer to the struct with flags of the command (see [Insert]). It may be nil.
+	Options any
+	// The short flag to full flag mappings, shortcuts of parent commands are
+	// also available. Shortcuts and aliases are also taken from tags of the
+	// options (see [TagShortcuts] and [TagAliases]), this map overrides them.
+	Shortcuts map[rune]string
+	// It runs the command after flags are inserted into the options.
+	Run func() error
+	// The subcommands.
+	Commands []*Command
+	// The parser settings of the root command are used for all commands, if
+	// it is nil, the zero parser is used.
+	Parser *Parser
+}
+
+// it finds the command from the arguments, inserts flags into the options of
+// the command and of its parents and runs the command.
+//
+// If a command isn't found, it returns the [UNKNOWN_COMMAND] error with
+// similar command names. If a command without [Command.Run] is called, it
+// returns the [NO_COMMAND] error. If [Parser.Strict] is true, flags, that
+// aren't bound to options of any of the commands, give the [UNKNOWN_FLAG] errors.
+//
+// If help is requested with "--help" or "-h", it writes help text of the
+// command (see [Command.Usage]) to [Parser.Output] and returns the [HELP] error.
+func (c *Command) Execute(args []string) error {
+	p := c.parser()
+
+	path, args, index, err := c.findPath(p, args)
+	if err != nil {
+		return err
+	}
+	cmd := path[len(path)-1]
+
+	cp, err := p.commandParser(path)
+	if err != nil {
+		return err
+	}
+
+	if cp.commandHelp(args, path) {
+		out := cp.Output
+		if out == nil {
+			out = os.Stdout
+		}
+		if err := cp.writeCommandUsage(out, path); err != nil {
+			return err
+		}
+		return HELP()
+	}
+
+	f, pos, err := cp.parse(args)
+	pos.remap(index)
+	if err != nil {
+		remapErrors(err, index)
+		return err
+	}
+
+	cp.Strict = false
+	unknown := maps.Clone(f)
+	delete(unknown, cp.ConfigFlag)
+	bound := []string{}
+	for _, command := range path {
+		if command.Options == nil {
+			continue
+		}
+		report, err := cp.insertParsed(f, pos, command.Options)
+		if err != nil {
+			return err
+		}
+		for _, field := range report.Fields {
+			bound = append(bound, field.Flag)
+			maps.DeleteFunc(unknown, func(name string, _ []string) bool {
+				return name == field.Flag || (cp.Normalize && normalize(name) == normalize(field.Flag))
+			})
+		}
+	}
+	if p.Strict && len(unknown) > 0 {
+		return mega("got some errors", unknownFlags(unknown, cp.flagCandidates(bound), pos))
+	}
+
+	if cmd.Run == nil {
+		return NO_COMMAND(commandName(path))
+	}
+	return cmd.Run()
+}
+
+// it finds the command from the arguments, flags of parent commands could be
+// written before their subcommands (e.g. `app -v serve --port 80`).
+//
+// It returns the arguments without the command names and the index of every
+// returned argument in the given arguments.
+func (c *Command) findPath(p *Parser, args []string) ([]*Command, []string, []int, error) {
+	path := []*Command{c}
+	rest := []string{}
+	index := []int{}
+	for i := 0; i < len(args); i++ {
+		cur := path[len(path)-1]
+		if len(cur.Commands) == 0 {
+			for ; i < len(args); i++ {
+				rest = append(rest, args[i])
+				index = append(index, i)
+			}
+			break
+		}
+
+		if !strings.HasPrefix(args[i], "-") {
+			next := cur.find(args[i])
+			if next == nil {
+				return nil, nil, nil, UNKNOWN_COMMAND(args[i], commandName(path), didYouMean(suggest(args[i], cur.names())))
+			}
+			path = append(path, next)
+			continue
+		}
+
+		cp, err := p.commandParser(path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		n := cp.flagValues(args[i], args[i+1:], path)
+		for j := i; j <= i+n; j++ {
+			rest = append(rest, args[j])
+			index = append(index, j)
+		}
+		i += n
+	}
+	return path, rest, index, nil
+}
+
+// it returns the number of arguments after the flag argument, that are
+// values of the flag, so the command after them could be found.
+//
+// A bool takes a value only if it is "true" or "false", slices take values
+// until a name of a subcommand, other fields take a single value. Unknown
+// flags don't take values.
+func (p *Parser) flagValues(arg string, next []string, path []*Command) int {
+	names := []string{}
+	if strings.HasPrefix(arg, "--") {
+		names = append(names, strings.TrimPrefix(arg, "--"))
+	} else if p.SingleDash == SingleDashNames && utf8.RuneCountInString(arg) > 2 {
+		names = append(names, strings.TrimPrefix(arg, "-"))
+	} else {
+		for _, s := range strings.TrimPrefix(arg, "-") {
+			names = append(names, p.Shortcuts[s])
+		}
+	}
+	if len(names) == 0 {
+		return 0
+	}
+
+	// Each shortcut, but the last one, takes a single value (see [Parse]).
+	res := 0
+	for res < len(names)-1 && res < len(next) && !strings.HasPrefix(next[res], "-") {
+		res++
+	}
+	next = next[res:]
+
+	name, err := p.resolve(names[len(names)-1])
+	if err != nil {
+		return res
+	}
+	cur := path[len(path)-1]
+	values := 0
+	for values < len(next) && (!strings.HasPrefix(next[values], "-") || isNegativeNumber(next[values], p.Shortcuts)) && cur.find(next[values]) == nil {
+		values++
+	}
+
+	t, ok := p.commandFlagType(name, path)
+	switch {
+	case !ok:
+		return res
+	case t.Kind() == reflect.Bool:
+		if values > 0 {
+			if _, err := strconv.ParseBool(next[0]); err == nil {
+				return res + 1
+			}
+		}
+		return res
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Interface:
+		return res + values
+	default:
+		return res + min(values, 1)
+	}
+}
+
+// it returns the type of the field with the flag in options of the commands.
+func (p *Parser) commandFlagType(name string, path []*Command) (reflect.Type, bool) {
+	for _, command := range path {
+		if command.Options == nil {
+			continue
+		}
+		for _, field := range p.flagFields(reflect.TypeOf(command.Options)) {
+			if field.name == name || (p.Normalize && normalize(field.name) == normalize(name)) {
+				t := field.Type
+				if t.Kind() == reflect.Pointer {
+					t = t.Elem()
+				}
+				return t, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// it returns help text of the command with its subcommands, flags and flags
+// of parent commands.
+func (c *Command) Usage() string {
+	var b strings.Builder
+	c.WriteUsage(&b)
+	return b.String()
+}
+
+// it writes help text of the command (see [Command.Usage]).
+func (c *Command) WriteUsage(w io.Writer) error {
+	p, err := c.parser().commandParser([]*Command{c})
+	if err != nil {
+		return err
+	}
+	return p.writeCommandUsage(w, []*Command{c})
+}
+
+// it writes a man page of the command with its subcommands (see [WriteMan]).
+func (c *Command) WriteMan(w io.Writer) error {
+	page, err := c.docPage([]*Command{c})
+	if err != nil {
+		return err
+	}
+	return writeMan(w, page)
+}
+
+// it writes a Markdown page of the command and pages of all its subcommands
+// (see [WriteMarkdown]).
+func (c *Command) WriteMarkdown(w io.Writer) error {
+	return c.writeMarkdown(w, []*Command{c})
+}
+
+func (c *Command) writeMarkdown(w io.Writer, path []*Command) error {
+	page, err := c.docPage(path)
+	if err != nil {
+		return err
+	}
+	if len(path) > 1 {
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	if err := writeMarkdown(w, page); err != nil {
+		return err
+	}
+
+	for _, sub := range c.Commands {
+		if err := sub.writeMarkdown(w, append(path[:len(path):len(path)], sub)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Command) docPage(path []*Command) (docPage, error) {
+	p, err := path[0].parser().commandParser(path)
+	if err != nil {
+		return docPage{}, err
+	}
+
+	page := docPage{name: commandName(path), description: c.Description}
+	for _, command := range path {
+		if command.Options == nil {
+			continue
+		}
+		docs, err := p.flagDocs(command.Options)
+		if err != nil {
+			return docPage{}, err
+		}
+		page.flags = append(page.flags, docs...)
+	}
+	for _, sub := range c.Commands {
+		page.commands = append(page.commands, [2]string{sub.Name, sub.Description})
+	}
+
+	return page, nil
+}
+
+func (p *Parser) writeCommandUsage(w io.Writer, path []*Command) error {
+	cmd := path[len(path)-1]
+
+	var b strings.Builder
+	b.WriteString("Usage: " + commandName(path))
+	if len(cmd.Commands) > 0 {
+		b.WriteString(" <command>")
+	}
+	b.WriteString(" [flags]\n")
+	if cmd.Description != "" {
+		b.WriteString("\n" + strings.Join(wrap(cmd.Description, p.width()), "\n") + "\n")
+	}
+
+	if len(cmd.Commands) > 0 {
+		b.WriteString("\nCommands:\n")
+		col := 0
+		for _, sub := range cmd.Commands {
+			col = max(col, len(sub.Name)+5)
+		}
+		for _, sub := range cmd.Commands {
+			description := sub.Description
+			if len(sub.Aliases) > 0 {
+				description = strings.TrimSpace(description + " (aliases: " + strings.Join(sub.Aliases, ", ") + ")")
+			}
+			writeColumns(&b, "  "+sub.Name, description, min(col, p.width()/2), p.width())
+		}
+	}
+
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i].Options == nil {
+			continue
+		}
+		var options strings.Builder
+		if err := p.WriteUsage(&options, path[i].Options); err != nil {
+			return err
+		}
+		usage := options.String()
+		if usage == "" {
+			continue
+		}
+		if i != len(path)-1 {
+			usage = strings.Replace(usage, "Flags:", "Global flags ("+commandName(path[:i+1])+"):", 1)
+		}
+		b.WriteString("\n" + usage)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// it reports whether help is requested for the command, "--help" and "-h"
+// request help only if options of the commands don't use them.
+func (p *Parser) commandHelp(args []string, path []*Command) bool {
+	if !p.helpRequested(args, struct{}{}) {
+		return false
+	}
+	for _, command := range path {
+		if command.Options != nil && !p.helpRequested(args, command.Options) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Command) parser() *Parser {
+	if c.Parser != nil {
+		return c.Parser
+	}
+	return new(Parser)
+}
+
+// it finds a subcommand by its name or alias.
+func (c *Command) find(name string) *Command {
+	for _, sub := range c.Commands {
+		if sub.Name == name {
+			return sub
+		}
+		for _, alias := range sub.Aliases {
+			if alias == name {
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+// it returns names and aliases of the subcommands.
+func (c *Command) names() []string {
+	res := []string{}
+	for _, sub := range c.Commands {
+		res = append(res, sub.Name)
+		res = append(res, sub.Aliases...)
+	}
+	return res
+}
+
+// it returns the full name of the command, e.g. "app migrate up".
+func commandName(path []*Command) string {
+	names := make([]string, len(path))
+	for i, command := range path {
+		names[i] = command.Name
+	}
+	return strings.Join(names, " ")
+}
+
+// it returns a copy of the parser with shortcuts and aliases of the commands,
+// shortcuts and aliases of subcommands override parents and shortcuts of the
+// parser (see [Parser.withTags]).
+func (p *Parser) commandParser(path []*Command) (*Parser, error) {
+	cp := *p
+	cp.KnownFlags = slices.Clone(p.KnownFlags)
+	cp.Shortcuts = make(map[rune]string)
+	maps.Copy(cp.Shortcuts, p.Shortcuts)
+	cp.Aliases = make(map[string]string)
+	for _, command := range path {
+		shortcuts, err := p.TagShortcuts(command.Options)
+		if err != nil {
+			return nil, err
+		}
+		aliases, err := p.TagAliases(command.Options)
+		if err != nil {
+			return nil, err
+		}
+
+		maps.Copy(cp.Shortcuts, shortcuts)
+		maps.Copy(cp.Shortcuts, command.Shortcuts)
+		maps.Copy(cp.Aliases, aliases)
+		cp.KnownFlags = append(cp.KnownFlags, p.flagNames(command.Options)...)
+	}
+	maps.Copy(cp.Aliases, p.Aliases)
+
+	return &cp, nil
+}
@@ -0,0 +1,195 @@
+package flags_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type globalOptions struct {
+	Verbose bool `usage:"Print more."`
+}
+
+type serveOptions struct {
+	Port int `usage:"Port to listen on."`
+}
+
+type upOptions struct {
+	Steps int
+}
+
+type app struct {
+	global *globalOptions
+	serve  *serveOptions
+	up     *upOptions
+	ran    string
+	cmd    *flags.Command
+	out    *bytes.Buffer
+}
+
+func newApp() *app {
+	a := &app{global: new(globalOptions), serve: new(serveOptions), up: new(upOptions), out: new(bytes.Buffer)}
+	a.cmd = &flags.Command{
+		Name:        "app",
+		Description: "Runs the app.",
+		Options:     a.global,
+		Shortcuts:   map[rune]string{'v': "verbose"},
+		Parser:      &flags.Parser{Output: a.out},
+		Commands: []*flags.Command{
+			{
+				Name:        "serve",
+				Aliases:     []string{"s"},
+				Description: "Starts the server.",
+				Options:     a.serve,
+				Shortcuts:   map[rune]string{'p': "port"},
+				Run:         func() error { a.ran = "serve"; return nil },
+			},
+			{
+				Name:        "migrate",
+				Description: "Changes the database.",
+				Commands: []*flags.Command{
+					{Name: "up", Options: a.up, Run: func() error { a.ran = "migrate up"; return nil }},
+				},
+			},
+		},
+	}
+	return a
+}
+
+func TestCommand(t *testing.T) {
+	a := newApp()
+	if err := a.cmd.Execute(strings.Fields("s -pv 3700")); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if a.ran != "serve" || !a.global.Verbose || a.serve.Port != 3700 {
+		t.Fatalf("got command %q with %+v %+v", a.ran, a.global, a.serve)
+	}
+
+	a = newApp()
+	if err := a.cmd.Execute(strings.Fields("migrate up --steps 2")); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if a.ran != "migrate up" || a.up.Steps != 2 || a.global.Verbose {
+		t.Fatalf("got command %q with %+v %+v", a.ran, a.global, a.up)
+	}
+}
+
+func TestCommandError(t *testing.T) {
+	err := newApp().cmd.Execute([]string{"serv"})
+	if !errors.Is(err, flags.UNKNOWN_COMMAND()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.UNKNOWN_COMMAND(), err)
+	}
+	if need := "unknown command 'serv' for app, did you mean serve?"; err.Error() != need {
+		t.Fatalf("got error %q, expected %q", err, need)
+	}
+
+	if err := newApp().cmd.Execute([]string{"migrate"}); !errors.Is(err, flags.NO_COMMAND()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.NO_COMMAND(), err)
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	a := newApp()
+	if err := a.cmd.Execute(strings.Fields("serve --help")); !errors.Is(err, flags.HELP()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.HELP(), err)
+	}
+
+	need := strings.Join([]string{
+		"Usage: app serve [flags]",
+		"",
+		"Starts the server.",
+		"",
+		"Flags:",
+		"  -p, --port int   Port to listen on.",
+		"",
+		"Global flags (app):",
+		"  -v, --verbose   Print more.",
+		"",
+	}, "\n")
+	if a.out.String() != need {
+		t.Fatalf("got usage:\n%s\nexpected:\n%s", a.out, need)
+	}
+
+	need = strings.Join([]string{
+		"Usage: app <command> [flags]",
+		"",
+		"Runs the app.",
+		"",
+		"Commands:",
+		"  serve     Starts the server. (aliases: s)",
+		"  migrate   Changes the database.",
+		"",
+		"Flags:",
+		"  -v, --verbose   Print more.",
+		"",
+	}, "\n")
+	if res := a.cmd.Usage(); res != need {
+		t.Fatalf("got usage:\n%s\nexpected:\n%s", res, need)
+	}
+}
+
+func TestCommandDocs(t *testing.T) {
+	var b bytes.Buffer
+	if err := newApp().cmd.WriteMarkdown(&b); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	golden(t, "command.md", b.Bytes())
+
+	b.Reset()
+	if err := newApp().cmd.WriteMan(&b); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	golden(t, "command.1", b.Bytes())
+}
+
+func TestCommandGlobalFlags(t *testing.T) {
+	for _, args := range []string{"--verbose serve --port 1", "-v serve -p 1", "--verbose true s --port 1", "serve -v --port 1"} {
+		a := newApp()
+		if err := a.cmd.Execute(strings.Fields(args)); err != nil {
+			t.Fatalf("got an error: %v for %q", err, args)
+		}
+		if a.ran != "serve" || !a.global.Verbose || a.serve.Port != 1 {
+			t.Fatalf("got command %q with %+v %+v for %q", a.ran, a.global, a.serve, args)
+		}
+	}
+
+	type options struct {
+		Env  string
+		Tags []string
+	}
+	opts := new(options)
+	ran := false
+	cmd := &flags.Command{
+		Name:    "app",
+		Options: opts,
+		Commands: []*flags.Command{
+			{Name: "run", Run: func() error { ran = true; return nil }},
+		},
+	}
+	if err := cmd.Execute(strings.Fields("--env 'prod' --tags 'a' 'b' run")); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if !ran || opts.Env != "prod" || len(opts.Tags) != 2 {
+		t.Fatalf("got %+v, ran %v", *opts, ran)
+	}
+
+	err := newApp().cmd.Execute(strings.Fields("-v serve --port x"))
+	var conv *flags.ConversionError
+	if !errors.As(err, &conv) || conv.Position != 3 {
+		t.Fatalf("got error %v, expected a conversion error at 3", err)
+	}
+}
+
+func TestCommandParserShortcuts(t *testing.T) {
+	a := newApp()
+	a.cmd.Parser.Shortcuts = map[rune]string{'P': "port", 'v': "port"}
+	if err := a.cmd.Execute(strings.Fields("serve -P 1 -v")); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if a.serve.Port != 1 || !a.global.Verbose {
+		t.Fatalf("got %+v %+v", a.global, a.serve)
+	}
+}
@@ -0,0 +1,362 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// the hidden command, that answers completion queries (see [Parser.Completers]).
+const completeCommand = "__complete"
+
+// it writes a bash completion script for a program with the flags of a struct.
+//
+// The script completes long flags, shortcuts, values from the `enum` tag and
+// paths for fields tagged with `complete:"file"` or `complete:"dir"`. Flags
+// without values (booleans) don't complete values.
+//
+// Example, add to .bashrc:
+// `source <(app completion bash)`
+func WriteBashCompletion(w io.Writer, name string, v any) error {
+	return new(Parser).WriteBashCompletion(w, name, v)
+}
+
+// it writes a zsh completion script (see [WriteBashCompletion]).
+func WriteZshCompletion(w io.Writer, name string, v any) error {
+	return new(Parser).WriteZshCompletion(w, name, v)
+}
+
+// it writes a fish completion script (see [WriteBashCompletion]).
+func WriteFishCompletion(w io.Writer, name string, v any) error {
+	return new(Parser).WriteFishCompletion(w, name, v)
+}
+
+// it writes a bash completion script using the parser settings.
+//
+// It works the same way as [WriteBashCompletion], shortcuts are taken from
+// [Parser.Shortcuts]. Values of flags with [Parser.Completers] are completed
+// by the program itself with the hidden "__complete" command (see [Parser.Load]).
+func (p *Parser) WriteBashCompletion(w io.Writer, name string, v any) error {
+	docs, err := p.flagDocs(v)
+	if err != nil {
+		return err
+	}
+
+	fn := "_" + shellName(name) + "_complete"
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s\n", name)
+	fmt.Fprintf(&b, "%s_reply() {\n", fn)
+	b.WriteString("    local cur=\"$1\" word\n    shift\n    COMPREPLY=()\n")
+	b.WriteString("    for word in \"$@\"; do\n")
+	b.WriteString("        local quoted\n        quoted=\"$(printf '%q' \"$word\")\"\n")
+	b.WriteString("        if [[ \"$word\" == \"$cur\"* || \"$quoted\" == \"$cur\"* ]]; then\n")
+	b.WriteString("            COMPREPLY+=(\"$quoted\")\n        fi\n    done\n}\n\n")
+
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	b.WriteString("    case \"$prev\" in\n")
+	for _, doc := range docs {
+		var action string
+		switch {
+		case p.Completers[doc.name] != nil:
+			action = fmt.Sprintf("local IFS=$'\\n'\n        %s_reply \"$cur\" $(%s %s \"${COMP_WORDS[@]:1:COMP_CWORD}\")", fn, shellQuote(name), completeCommand)
+		case doc.enum != nil:
+			action = fn + "_reply \"$cur\" " + strings.Join(mapStrings(completionValues(doc), shellQuote), " ")
+		case doc.complete == "file":
+			action = "COMPREPLY=($(compgen -f -- \"$cur\"))"
+		case doc.complete == "dir":
+			action = "COMPREPLY=($(compgen -d -- \"$cur\"))"
+		default:
+			continue
+		}
+
+		b.WriteString("    " + strings.Join(mapStrings(flagForms(doc), shellQuote), "|") + ")\n")
+		b.WriteString("        " + action + "\n        return\n        ;;\n")
+	}
+	b.WriteString("    esac\n")
+
+	b.WriteString("    if [[ \"$cur\" == -* ]]; then\n")
+	b.WriteString("        " + fn + "_reply \"$cur\"")
+	for _, doc := range docs {
+		for _, form := range flagForms(doc) {
+			b.WriteString(" " + shellQuote(form))
+		}
+	}
+	b.WriteString("\n    fi\n}\n\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", fn, shellQuote(name))
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// it writes a zsh completion script using the parser settings (see [Parser.WriteBashCompletion]).
+func (p *Parser) WriteZshCompletion(w io.Writer, name string, v any) error {
+	docs, err := p.flagDocs(v)
+	if err != nil {
+		return err
+	}
+
+	fn := "_" + shellName(name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n\n", name)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("    _arguments -s \\\n")
+	for _, doc := range docs {
+		description := ""
+		if doc.usage != "" {
+			description = "[" + strings.ReplaceAll(strings.ReplaceAll(doc.usage, "]", `\]`), ":", `\:`) + "]"
+		}
+
+		action := ""
+		switch {
+		case p.Completers[doc.name] != nil:
+			action = fmt.Sprintf(":%s:{compadd -- ${(f)\"$(%s %s ${words[2,CURRENT]})\"}}", doc.name, shellQuote(name), completeCommand)
+		case doc.enum != nil:
+			action = fmt.Sprintf(":%s:(%s)", doc.name, strings.Join(mapStrings(completionValues(doc), zshQuote), " "))
+		case doc.complete == "file":
+			action = ":" + doc.name + ":_files"
+		case doc.complete == "dir":
+			action = ":" + doc.name + ":_files -/"
+		case doc.typ != "":
+			action = ":" + doc.name + ": "
+		}
+
+		forms := flagForms(doc)
+		spec := forms[0]
+		if len(forms) > 1 {
+			spec = "{" + strings.Join(forms, ",") + "}"
+		}
+		b.WriteString("        " + spec + shellQuote(description+action) + " \\\n")
+	}
+	b.WriteString("        '*: :'\n}\n\n")
+	fmt.Fprintf(&b, "compdef %s %s\n", fn, name)
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// it writes a fish completion script using the parser settings (see [Parser.WriteBashCompletion]).
+func (p *Parser) WriteFishCompletion(w io.Writer, name string, v any) error {
+	docs, err := p.flagDocs(v)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# fish completion for %s\n", name)
+	for _, doc := range docs {
+		line := "complete -c " + fishQuote(name) + " -l " + fishQuote(doc.name)
+		for _, alias := range doc.aliases {
+			line += " -l " + fishQuote(alias)
+		}
+		if doc.short != 0 {
+			line += " -s " + fishQuote(string(doc.short))
+		}
+
+		switch {
+		case p.Completers[doc.name] != nil:
+			line += " -x -a " + fishQuote(fmt.Sprintf("(%s %s (commandline -opc)[2..-1] (commandline -ct))", fishQuote(name), completeCommand))
+		case doc.enum != nil:
+			line += " -x -a " + fishQuote(strings.Join(mapStrings(completionValues(doc), fishQuote), " "))
+		case doc.complete == "file":
+			line += " -r -F"
+		case doc.complete == "dir":
+			line += " -x -a " + fishQuote("(__fish_complete_directories)")
+		case doc.typ != "":
+			line += " -r"
+		}
+
+		if doc.usage != "" {
+			line += " -d " + fishQuote(doc.usage)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// it answers a completion query from a completion script.
+//
+// The words are the arguments after the program name, the last word is the
+// word being completed.
+func (p *Parser) complete(words []string, v any) ([]string, error) {
+	docs, err := p.flagDocs(v)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := ""
+	if len(words) > 0 {
+		cur = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	res := []string{}
+	if strings.HasPrefix(cur, "-") {
+		for _, doc := range docs {
+			for _, form := range flagForms(doc) {
+				if strings.HasPrefix(form, cur) {
+					res = append(res, form)
+				}
+			}
+		}
+		return res, nil
+	}
+
+	doc := p.completedFlag(words, docs)
+	if doc == nil {
+		return res, nil
+	}
+
+	if completer := p.Completers[doc.name]; completer != nil {
+		return completer(cur), nil
+	}
+	for _, val := range completionValues(*doc) {
+		if strings.HasPrefix(val, cur) {
+			res = append(res, val)
+		}
+	}
+	return res, nil
+}
+
+// it returns the flag, that the next word is a value of, or nil.
+//
+// Words are matched with flags the same way as [Parser.parse] does: negative
+// numbers are values, a single dash word is a cluster of known shortcuts
+// (or a flag name with [SingleDashNames]) and values after a cluster are
+// given to its flags in order, the last flag takes the rest.
+func (p *Parser) completedFlag(words []string, docs []flagDoc) *flagDoc {
+	shortcuts := make(map[rune]string)
+	for _, doc := range docs {
+		if doc.short != 0 {
+			shortcuts[doc.short] = doc.name
+		}
+	}
+	find := func(form string) *flagDoc {
+		for i := range docs {
+			if slices.Contains(flagForms(docs[i]), form) {
+				return &docs[i]
+			}
+		}
+		return nil
+	}
+
+	for i := len(words) - 1; i >= 0; i-- {
+		word := words[i]
+		if !strings.HasPrefix(word, "-") || isNegativeNumber(word, shortcuts) {
+			continue
+		}
+		if strings.HasPrefix(word, "--") {
+			return find(word)
+		}
+		if p.SingleDash == SingleDashNames && utf8.RuneCountInString(word) > 2 {
+			return find("-" + word)
+		}
+
+		cluster := []*flagDoc{}
+		for _, s := range strings.TrimPrefix(word, "-") {
+			doc := find("-" + string(s))
+			if doc == nil {
+				return nil
+			}
+			cluster = append(cluster, doc)
+		}
+		if len(cluster) == 0 {
+			return nil
+		}
+		return cluster[min(len(words)-1-i, len(cluster)-1)]
+	}
+	return nil
+}
+
+// it handles the hidden "__complete" command, it reports whether the
+// arguments are a completion query.
+func (p *Parser) completeArgs(args []string, v any) (bool, error) {
+	if len(args) == 0 || args[0] != completeCommand || p.hasFlag(v, completeCommand) {
+		return false, nil
+	}
+
+	res, err := p.complete(args[1:], v)
+	if err != nil {
+		return true, err
+	}
+
+	out := p.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	for _, val := range res {
+		if _, err := fmt.Fprintln(out, val); err != nil {
+			return true, err
+		}
+	}
+
+	return true, COMPLETE()
+}
+
+// it returns the flag and the shortcut as they are written in the command line.
+func flagForms(doc flagDoc) []string {
+	forms := []string{"--" + doc.name}
+	for _, alias := range doc.aliases {
+		forms = append(forms, "--"+alias)
+	}
+	if doc.short != 0 {
+		forms = append(forms, "-"+string(doc.short))
+	}
+	return forms
+}
+
+// it returns values from the `enum` tag as they are written in the command
+// line, values for strings are in brackets.
+func completionValues(doc flagDoc) []string {
+	if !strings.HasPrefix(doc.typ, "string") {
+		return doc.enum
+	}
+	return mapStrings(doc.enum, func(s string) string { return "'" + s + "'" })
+}
+
+func mapStrings(s []string, f func(string) string) []string {
+	res := make([]string, len(s))
+	for i, el := range s {
+		res[i] = f(el)
+	}
+	return res
+}
+
+// it returns the name with only letters, digits and "_".
+func shellName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// it quotes a word for bash and zsh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// it quotes a word inside of a zsh value list.
+func zshQuote(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(` '"\()[]{}:`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// it quotes a word for fish.
+func fishQuote(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", `\'`) + "'"
+}
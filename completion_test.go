@@ -0,0 +1,88 @@
+package flags_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type withCompletion struct {
+	Env     string `usage:"Environment to deploy to."`
+	Level   string `usage:"Log level." enum:"debug,info"`
+	Workers int    `enum:"1,2,4"`
+	Config  string `usage:"Config file." complete:"file"`
+	Dry     bool   `usage:"Don't change anything."`
+}
+
+func completionParser(out *bytes.Buffer) *flags.Parser {
+	return &flags.Parser{
+		Shortcuts: map[rune]string{'e': "env", 'd': "dry"},
+		Completers: map[string]func(string) []string{
+			"env": func(prefix string) []string {
+				res := []string{}
+				for _, env := range []string{"'prod'", "'staging'", "'dev'"} {
+					if strings.HasPrefix(env, prefix) {
+						res = append(res, env)
+					}
+				}
+				return res
+			},
+		},
+		Output: out,
+	}
+}
+
+func TestCompletionScripts(t *testing.T) {
+	p := completionParser(nil)
+
+	for name, write := range map[string]func(*bytes.Buffer) error{
+		"app.bash": func(b *bytes.Buffer) error { return p.WriteBashCompletion(b, "app", new(withCompletion)) },
+		"app.zsh":  func(b *bytes.Buffer) error { return p.WriteZshCompletion(b, "app", new(withCompletion)) },
+		"app.fish": func(b *bytes.Buffer) error { return p.WriteFishCompletion(b, "app", new(withCompletion)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := write(&b); err != nil {
+				t.Fatalf("got an error: %v", err)
+			}
+			golden(t, name, b.Bytes())
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	cases := []struct {
+		args []string
+		res  []string
+	}{
+		{[]string{"__complete", "--l"}, []string{"--level"}},
+		{[]string{"__complete", "-"}, []string{"--env", "-e", "--level", "--workers", "--config", "--dry", "-d"}},
+		{[]string{"__complete", "--level", ""}, []string{"'debug'", "'info'"}},
+		{[]string{"__complete", "--workers", "4"}, []string{"4"}},
+		{[]string{"__complete", "--dry", "-e", "'s"}, []string{"'staging'"}},
+		{[]string{"__complete", "--dry", ""}, nil},
+		{[]string{"__complete", "-ed", "'d"}, []string{"'dev'"}},
+		{[]string{"__complete", "-de", "'d"}, nil},
+		{[]string{"__complete", "-de", "true", "'d"}, []string{"'dev'"}},
+		{[]string{"__complete", "-xe", "'d"}, nil},
+		{[]string{"__complete", "--level", "-dev", ""}, nil},
+		{[]string{"__complete", "--workers", "-1", ""}, []string{"1", "2", "4"}},
+	}
+
+	for _, tc := range cases {
+		var out bytes.Buffer
+		err := completionParser(&out).Load(tc.args, new(withCompletion))
+		if !errors.Is(err, flags.COMPLETE()) {
+			t.Fatalf("got different errors expected %v, got %v", flags.COMPLETE(), err)
+		}
+
+		res := strings.Fields(out.String())
+		if !slices.Equal(tc.res, res) && (len(tc.res) != 0 || len(res) != 0) {
+			t.Fatalf("got different completions for %q: expected %q, got %q", tc.args, tc.res, res)
+		}
+	}
+}
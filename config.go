@@ -0,0 +1,196 @@
+package flags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// it reads a JSON config file and converts it to flags for a struct.
+//
+// The keys of the JSON object are the same flag names [Insert] uses for
+// the fields of `v`. Nested objects are used for nested structs, if a
+// nested struct has no object, its fields are taken from the same object.
+//
+// Example, for a struct with field `Port` and nested struct field `Db` with field `Host`:
+// `{"port": 3700, "db": {"host": "localhost"}}`
+//
+// JSON strings are quoted for string fields, arrays give multiple values,
+// nulls are skipped. Every value is checked with the same conversion rules
+// as command-line values.
+//
+// It returns a map in the same form as [Parse], so it could be merged with
+// command-line flags and inserted with [Insert].
+func ReadJSON(path string, v any) (map[string][]string, error) {
+	return new(Parser).ReadJSON(path, v)
+}
+
+// it reads a JSON config file and converts it to flags for a struct using
+// the parser settings.
+//
+// It works the same way as [ReadJSON].
+func (p *Parser) ReadJSON(path string, v any) (map[string][]string, error) {
+	rt := reflect.TypeOf(v)
+	if rt == nil || rt.Kind() != reflect.Pointer {
+		return nil, TYPE_ERROR()
+	}
+	rt = rt.Elem()
+	if rt.Kind() != reflect.Struct {
+		return nil, IS_NOT_A_STRUCT()
+	}
+
+	data, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, CANT_READ_CONFIG(path, readErr)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj map[string]any
+	if decodeErr := dec.Decode(&obj); decodeErr != nil {
+		return nil, CANT_READ_CONFIG(path, decodeErr)
+	}
+
+	res := make(map[string][]string)
+	if err := p.jsonFlags(res, obj, rt, "", "", path, structNaming(rt, "", p.naming())); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (p *Parser) jsonFlags(res map[string][]string, obj map[string]any, t reflect.Type, prefix string, path string, file string, naming NameFunc) error {
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		fieldName := fieldFlagName(fieldType, naming)
+		if fieldName == "-" || !fieldType.IsExported() {
+			continue
+		}
+
+		ft := fieldType.Type
+		isTime := ft == timeType
+		if !isTime && indirectType(ft).Kind() == reflect.Struct {
+			key := fieldType.Tag.Get("prefix")
+			if key == "" {
+				key = fieldName
+			}
+
+			inner := obj
+			innerPath := path
+			if nested, ok := obj[key].(map[string]any); ok {
+				inner = nested
+				innerPath = jsonPath(path, key)
+			}
+			if err := p.jsonFlags(res, inner, indirectType(ft), nestedPrefix(prefix, fieldType), innerPath, file, fieldNaming(fieldType, naming)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		val, exist := obj[fieldName]
+		if !exist || val == nil {
+			continue
+		}
+
+		valPath := jsonPath(path, fieldName)
+		fieldName = prefix + fieldName
+		args, ok := jsonArgs(val, ft)
+		if !ok {
+			return BAD_CONFIG(file, valPath, val, UNSUPPORTABLE_TYPE(reflect.TypeOf(val).String()))
+		}
+
+		check := reflect.New(ft).Elem()
+		var err error
+		if isTime {
+			err = setTime(check, args, fieldName)
+		} else {
+			err = setValue(args, check, fieldName)
+		}
+		if err != nil {
+			return BAD_CONFIG(file, valPath, val, err)
+		}
+
+		res[fieldName] = args
+	}
+
+	return nil
+}
+
+// it converts a JSON value to the same form as command-line values.
+func jsonArgs(val any, t reflect.Type) ([]string, bool) {
+	t = indirectType(t)
+
+	vals := []any{val}
+	if list, ok := val.([]any); ok {
+		vals = list
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			t = indirectType(t.Elem())
+		}
+	}
+
+	args := make([]string, 0, len(vals))
+	for _, el := range vals {
+		switch el := el.(type) {
+		case string:
+			if t.Kind() == reflect.String || t.Kind() == reflect.Interface {
+				el = quoteString(el)
+			}
+			args = append(args, el)
+		case json.Number:
+			if t.Kind() == reflect.String {
+				args = append(args, quoteString(el.String()))
+			} else {
+				args = append(args, el.String())
+			}
+		case bool:
+			if t.Kind() == reflect.String {
+				args = append(args, quoteString(fmt.Sprint(el)))
+			} else {
+				args = append(args, fmt.Sprint(el))
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	return args, true
+}
+
+func jsonPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return strings.Join([]string{path, key}, ".")
+}
+
+// it reads the config file given with [Parser.ConfigFlag] as a source.
+//
+// It returns nil if there is no config file.
+func (p *Parser) config(flags map[string][]string, v any) (*Source, error) {
+	if p.ConfigFlag == "" {
+		return nil, nil
+	}
+
+	paths, exist := flags[p.ConfigFlag]
+	if !exist {
+		return nil, nil
+	}
+	if len(paths) != 1 {
+		return nil, TOO_MANY_ARGUMENTS(p.ConfigFlag)
+	}
+
+	path := paths[0]
+	if s, ok := convertString(path); ok {
+		path = s
+	}
+
+	conf, err := p.ReadJSON(path, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Source{Name: path, Flags: conf}, nil
+}
@@ -0,0 +1,78 @@
+package flags_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vandi37/flags"
+)
+
+type db struct {
+	Addr    string
+	Timeout time.Duration
+}
+
+type withConfig struct {
+	Port  int
+	Host  string
+	Tags  []string
+	Debug bool
+	Db    db
+	Any   any
+}
+
+func writeFile(t *testing.T, name string, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	return path
+}
+
+func TestConfig(t *testing.T) {
+	path := writeFile(t, "config.json", `{
+		"port": 8080,
+		"host": "example.com",
+		"tags": ["a", "b"],
+		"debug": true,
+		"db": {"addr": "db.local", "timeout": "5s"},
+		"any": "text"
+	}`)
+
+	p := &flags.Parser{ConfigFlag: "config"}
+	val := new(withConfig)
+	err := p.Load(append(strings.Fields("--port 3700 --config"), path), val)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	need := withConfig{3700, "example.com", []string{"a", "b"}, true, db{"db.local", 5 * time.Second}, "text"}
+	if val.Port != need.Port || val.Host != need.Host || !slices.Equal(val.Tags, need.Tags) || val.Debug != need.Debug || val.Db != need.Db || val.Any != need.Any {
+		t.Fatalf("got structure %+v, expected %+v", val, need)
+	}
+}
+
+func TestConfigError(t *testing.T) {
+	path := writeFile(t, "config.json", `{"db": {"timeout": "soon"}}`)
+
+	_, err := flags.ReadJSON(path, new(withConfig))
+	if !errors.Is(err, flags.BAD_CONFIG()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.BAD_CONFIG(), err)
+	}
+	for _, part := range []string{path, "db.timeout", "soon"} {
+		if !strings.Contains(err.Error(), part) {
+			t.Fatalf("error %q doesn't contain %q", err, part)
+		}
+	}
+
+	_, err = flags.ReadJSON(writeFile(t, "bad.json", `{"port":`), new(withConfig))
+	if !errors.Is(err, flags.CANT_READ_CONFIG()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.CANT_READ_CONFIG(), err)
+	}
+}
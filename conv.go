@@ -1,12 +1,146 @@
 package flags
 
 import (
-	"regexp"
+	"reflect"
 	"strings"
+	"unicode"
 )
 
-func camelToSnake(s string) string {
-	re := regexp.MustCompile("([A-Z])")
-	snake := re.ReplaceAllString(s, "_$1")
-	return strings.ToLower(strings.TrimPrefix(snake, "_"))
+// it converts a field name to a flag name (see [Parser.Naming]).
+type NameFunc func(field string) string
+
+// it is the default naming strategy for [Parser.Naming], every upper case
+// letter starts a new word: `HTTPPort` is "h_t_t_p_port" and `UserID` is
+// "user_i_d". It is kept, so flag names of existing programs don't change,
+// use [SnakeCase] for acronym-aware names.
+func LegacySnakeCase(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
+
+// it is the snake case naming strategy, acronyms are kept as one word:
+// `HTTPPort` is "http_port".
+func SnakeCase(field string) string { return strings.ToLower(strings.Join(splitWords(field), "_")) }
+
+// it is the kebab case naming strategy: `HTTPPort` is "http-port".
+func KebabCase(field string) string { return strings.ToLower(strings.Join(splitWords(field), "-")) }
+
+// it is the dot case naming strategy: `HTTPPort` is "http.port".
+func DotCase(field string) string { return strings.ToLower(strings.Join(splitWords(field), ".")) }
+
+// it is the lower camel case naming strategy: `HTTPPort` is "httpPort".
+func LowerCamelCase(field string) string {
+	words := splitWords(field)
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			word = string(runes)
+		}
+		words[i] = word
+	}
+	return strings.Join(words, "")
+}
+
+// it keeps field names as they are: `HTTPPort` is "HTTPPort".
+func ExactCase(field string) string { return field }
+
+// The package naming strategies, fields are cached for them (see [Parser.flagFields]).
+// They are functions, so they can't be changed and are safe to compare.
+var builtinNamings = []NameFunc{LegacySnakeCase, SnakeCase, KebabCase, DotCase, LowerCamelCase, ExactCase}
+
+// The naming strategies for the `naming` tag.
+var namings = map[string]NameFunc{
+	"legacy":     LegacySnakeCase,
+	"snake":      SnakeCase,
+	"kebab":      KebabCase,
+	"dot":        DotCase,
+	"lowerCamel": LowerCamelCase,
+	"exact":      ExactCase,
+}
+
+// it returns the naming strategy for a value of the `naming` tag ("legacy",
+// "snake", "kebab", "dot", "lowerCamel" or "exact").
+func TagNaming(name string) (NameFunc, bool) {
+	f, ok := namings[name]
+	return f, ok
+}
+
+// it is implemented by structs, that name flags of their fields themselves.
+//
+// It overrides [Parser.Naming] for the fields of the struct and of its nested
+// structs.
+type Namer interface {
+	FlagName(field string) string
+}
+
+var namerType = reflect.TypeOf((*Namer)(nil)).Elem()
+
+// it splits a camel case name into words, acronyms are one word.
+//
+// Example:
+// `HTTPPort` is "HTTP" and "Port", `UserID` is "User" and "ID".
+func splitWords(s string) []string {
+	runes := []rune(s)
+	words := []string{}
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		if !unicode.IsUpper(cur) {
+			continue
+		}
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// it returns the naming strategy for fields of a struct.
+//
+// The `naming` tag of the field with the struct is used first, then the
+// [Namer] interface of the struct, otherwise the naming of the parent struct
+// is kept.
+func structNaming(t reflect.Type, tag string, naming NameFunc) NameFunc {
+	if f, ok := namings[tag]; ok {
+		return f
+	}
+
+	t = indirectType(t)
+	if t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(namerType) {
+		return reflect.New(t).Interface().(Namer).FlagName
+	}
+	return naming
+}
+
+// it returns the index of the naming strategy of the parser in
+// builtinNamings, it reports false for other strategies.
+func (p *Parser) namingIndex() (int, bool) {
+	naming := reflect.ValueOf(p.naming()).Pointer()
+	for i, f := range builtinNamings {
+		if reflect.ValueOf(f).Pointer() == naming {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// it returns the naming strategy of the parser, it is [LegacySnakeCase] if
+// [Parser.Naming] is nil.
+func (p *Parser) naming() NameFunc {
+	if p.Naming != nil {
+		return p.Naming
+	}
+	return LegacySnakeCase
 }
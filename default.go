@@ -2,49 +2,24 @@ package flags
 
 import (
 	"strconv"
-	"strings"
-	"time"
-)
-
-var quotationMarks = []string{`"`, "'", "`"}
-
-func convertString(s string) (string, bool) {
-	for _, mark := range quotationMarks {
-		if strings.HasPrefix(s, mark) && strings.HasSuffix(s, mark) {
-			return strings.TrimPrefix(strings.TrimSuffix(s, mark), mark), true
-		}
-	}
-
-	return "", false
-}
-
-func convertInt(s string, size int) (int64, bool) {
-	if n, err := strconv.ParseInt(s, 10, size); err == nil {
-		return n, true
-	}
-	for base := 2; base <= 16; base++ {
-		if n, err := strconv.ParseInt(s, base, size); err == nil {
-			return n, true
-		}
-	}
 
-	if n, err := time.ParseDuration(s); err == nil {
-		return int64(n), true
-	}
-	return 0, false
-}
+	"github.com/vandi37/flags/internal/convert"
+)
 
-func convertUint(s string, size int) (uint64, bool) {
-	if n, err := strconv.ParseUint(s, 10, size); err == nil {
-		return n, true
-	}
-	for base := 2; base <= 16; base++ {
-		if n, err := strconv.ParseUint(s, base, size); err == nil {
-			return n, true
-		}
-	}
-	return 0, false
-}
+// The conversions are shared with the bind package, so generated code
+// converts values the same way (see [convert]).
+var (
+	convertString     = convert.String
+	quoteString       = convert.Quote
+	convertInt        = convert.Int
+	convertUint       = convert.Uint
+	convertBoolArg    = convert.BoolArg
+	convertIntArg     = convert.IntArg
+	convertUintArg    = convert.UintArg
+	convertFloatArg   = convert.FloatArg
+	convertComplexArg = convert.ComplexArg
+	convertStringArg  = convert.StringArg
+)
 
 func defaultConvert(s string) any {
 	if s, ok := convertString(s); ok {
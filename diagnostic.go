@@ -0,0 +1,218 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// it selects whether diagnostics are colored (see [Parser.Color]).
+type ColorMode int
+
+const (
+	// Colors are used if the output is a terminal, the NO_COLOR environment
+	// variable isn't set and TERM isn't "dumb".
+	ColorAuto ColorMode = iota
+	// Colors are always used.
+	ColorAlways
+	// Colors are never used.
+	ColorNever
+)
+
+const (
+	colorError = "\x1b[1;31m"
+	colorHint  = "\x1b[1;36m"
+	colorReset = "\x1b[0m"
+)
+
+// it returns errors of [Load] as diagnostics for users.
+//
+// Every error (errors of [megaError] are split) is written with the command
+// line and the offending argument is underlined, with a hint (the expected
+// type, the allowed values or similar flags), if there is one.
+//
+// Example:
+//
+//	error: field Port: cant convert value 'x' to type int: invalid syntax
+//	  --port x --level 'warn'
+//	         ^
+//	  hint: expected int
+//
+// The arguments have to be the same as the arguments given to [Load] (with
+// [Parser.ResponseFiles] the expanded arguments, see [ExpandResponseFiles]),
+// errors without a position (e.g. from the environment) are written without
+// the command line.
+func Diagnostics(args []string, err error) string {
+	return new(Parser).Diagnostics(args, err)
+}
+
+// it writes errors of [Load] as diagnostics for users (see [Diagnostics]).
+func WriteDiagnostics(w io.Writer, args []string, err error) error {
+	return new(Parser).WriteDiagnostics(w, args, err)
+}
+
+// it returns errors as diagnostics for users using the parser settings (see
+// [Diagnostics]), colors are used only with [ColorAlways].
+func (p *Parser) Diagnostics(args []string, err error) string {
+	var b strings.Builder
+	p.writeDiagnostics(&b, args, err, p.Color == ColorAlways)
+	return b.String()
+}
+
+// it writes errors as diagnostics for users using the parser settings (see
+// [Diagnostics]), colors are selected with [Parser.Color].
+func (p *Parser) WriteDiagnostics(w io.Writer, args []string, err error) error {
+	var b strings.Builder
+	p.writeDiagnostics(&b, args, err, p.colored(w))
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// it reports whether diagnostics written to the writer are colored.
+func (p *Parser) colored(w io.Writer) bool {
+	switch p.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if _, ok := p.lookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if term, _ := p.lookupEnv("TERM"); term == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// it is a single error prepared for writing.
+type diagnostic struct {
+	message string
+	hint    string
+	// The index of the argument, or -1.
+	position int
+	// The index of the rune in the argument, or -1 to underline the whole argument.
+	offset int
+}
+
+func (p *Parser) writeDiagnostics(b *strings.Builder, args []string, err error, color bool) {
+	paint := func(s string, c string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+
+	for i, err := range splitErrors(err) {
+		d := diagnose(err)
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(paint("error:", colorError) + " " + d.message + "\n")
+
+		if d.position >= 0 && d.position < len(args) {
+			col := 0
+			for _, arg := range args[:d.position] {
+				col += utf8.RuneCountInString(arg) + 1
+			}
+			mark := "^" + strings.Repeat("~", max(utf8.RuneCountInString(args[d.position])-1, 0))
+			if d.offset >= 0 {
+				col += d.offset
+				mark = "^"
+			}
+
+			b.WriteString("  " + strings.Join(args, " ") + "\n")
+			b.WriteString("  " + strings.Repeat(" ", col) + paint(mark, colorError) + "\n")
+		}
+
+		if d.hint != "" {
+			b.WriteString("  " + paint("hint:", colorHint) + " " + d.hint + "\n")
+		}
+	}
+}
+
+// it splits errors of [megaError] into single errors.
+func splitErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	var m *megaError
+	if !errors.As(err, &m) || m != err {
+		return []error{err}
+	}
+
+	res := []error{}
+	for _, err := range m.Errs {
+		res = append(res, splitErrors(err)...)
+	}
+	return res
+}
+
+// it prepares an error for writing.
+func diagnose(err error) diagnostic {
+	res := diagnostic{message: err.Error(), position: -1, offset: -1}
+
+	var conv *ConversionError
+	var arity *ArityError
+	var enum *NotAllowedError
+	var unknown *UnknownFlagError
+	var short *UnknownShortcutError
+	var field *FieldError
+	var arg *ArgumentError
+	switch {
+	case errors.As(err, &conv):
+		res.position = conv.Position
+		res.hint = "expected " + conv.TargetType
+		if conv.TargetType == "interface {}" {
+			res.hint = "expected a string, a number, a bool, a time or a complex number"
+		}
+	case errors.As(err, &arity):
+		res.position = arity.Position
+		res.hint = fmt.Sprintf("expected at most %d values", arity.Max)
+		if arity.Max == 1 {
+			res.hint = "expected a single value"
+		}
+	case errors.As(err, &enum):
+		res.position = enum.Position
+		res.message, _, _ = strings.Cut(res.message, ", allowed values: ")
+		res.hint = "allowed values: " + strings.Join(enum.Allowed, ", ")
+	case errors.As(err, &unknown):
+		res.position = unknown.Position
+		res.message = "unknown flag --" + unknown.Flag
+		res.hint = strings.TrimPrefix(didYouMean(unknown.Suggestions), ", ")
+	case errors.As(err, &short):
+		res.position, res.offset = short.Position, short.Offset
+		res.message = fmt.Sprintf("shortcut '%c' does not exist", short.Shortcut)
+		res.hint = strings.TrimPrefix(didYouMean(short.Suggestions), ", ")
+	case errors.As(err, &field):
+		res.position = field.Position
+	case errors.As(err, &arg):
+		res.position = arg.Position
+	}
+
+	return res
+}
+
+// it replaces positions of errors of parsing in cut arguments with positions
+// in the full arguments (see [positions.remap] and [Command.Execute]).
+func remapErrors(err error, index []int) {
+	for _, err := range splitErrors(err) {
+		var arg *ArgumentError
+		var short *UnknownShortcutError
+		if errors.As(err, &arg) {
+			arg.Position = remapPosition(arg.Position, index)
+		} else if errors.As(err, &short) {
+			short.Position = remapPosition(short.Position, index)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package flags_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+func TestDiagnostics(t *testing.T) {
+	args := strings.Fields("--port x --level 'warn' --prot 1")
+	p := &flags.Parser{Strict: true, Validate: true}
+	err := p.Load(args, new(withErrors))
+
+	need := strings.Join([]string{
+		"error: field Port: cant convert value 'x' to type int: invalid syntax",
+		"  --port x --level 'warn' --prot 1",
+		"         ^",
+		"  hint: expected int",
+		"",
+		"error: field Level: value 'warn' isn't allowed for flag level",
+		"  --port x --level 'warn' --prot 1",
+		"                   ^~~~~~",
+		"  hint: allowed values: debug, info",
+		"",
+		"error: unknown flag --prot",
+		"  --port x --level 'warn' --prot 1",
+		"                          ^~~~~~",
+		"  hint: did you mean --port?",
+		"",
+	}, "\n")
+	if got := p.Diagnostics(args, err); got != need {
+		t.Fatalf("got diagnostics:\n%s\nexpected:\n%s", got, need)
+	}
+
+	args = strings.Fields("-vx 1")
+	err = flags.LoadWithShortcuts(args, new(withErrors), map[rune]string{'v': "verbose"})
+	need = strings.Join([]string{
+		"error: shortcut 'x' does not exist",
+		"  -vx 1",
+		"    ^",
+		"",
+	}, "\n")
+	got := (&flags.Parser{Color: flags.ColorNever}).Diagnostics(args, err)
+	if got != need {
+		t.Fatalf("got diagnostics:\n%s\nexpected:\n%s", got, need)
+	}
+
+	got = (&flags.Parser{Color: flags.ColorAlways}).Diagnostics(args, err)
+	if !strings.Contains(got, "\x1b[1;31merror:\x1b[0m") {
+		t.Fatalf("got diagnostics without colors: %q", got)
+	}
+}
@@ -0,0 +1,80 @@
+package flags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// it is a field with different values in two structs (see [Diff]).
+type Change struct {
+	// The path of the field, nested struct fields are separated by dots (e.g. "Db.Host").
+	Field string
+	// The flag name of the field.
+	Flag string
+	// The values of the field in the first and the second struct.
+	Old any
+	New any
+}
+
+// it returns the change as "--flag: old -> new", values are written the same
+// way as in the command line (see [Marshal]).
+func (c Change) String() string {
+	return fmt.Sprintf("--%s: %s -> %s", c.Flag, changeValue(c.Old), changeValue(c.New))
+}
+
+func changeValue(v any) string {
+	args, err := marshalValue(reflect.ValueOf(v))
+	if err != nil || args == nil {
+		return fmt.Sprint(v)
+	}
+	return strings.Join(args, " ")
+}
+
+// it compares the fields, that are bound to flags, of two structs of the same
+// type and returns the fields with different values in the order of the
+// struct.
+//
+// Example, with port 80 in a and 8080 in b:
+//
+//	changes, err := flags.Diff(a, b)
+//	fmt.Println(changes[0]) // --port: 80 -> 8080
+//
+// It returns the [DIFFERENT_TYPES] error if the structs have different types.
+func Diff(a, b any) ([]Change, error) {
+	return new(Parser).Diff(a, b)
+}
+
+// it compares two structs with flag names of the parser (see [Diff] and
+// [Parser.Naming]).
+func (p *Parser) Diff(a, b any) ([]Change, error) {
+	va, vb := reflect.Indirect(reflect.ValueOf(a)), reflect.Indirect(reflect.ValueOf(b))
+	if !va.IsValid() || !vb.IsValid() {
+		return nil, TYPE_ERROR()
+	}
+	if va.Type() != vb.Type() {
+		return nil, DIFFERENT_TYPES(va.Type(), vb.Type())
+	}
+	if va.Kind() != reflect.Struct {
+		return nil, IS_NOT_A_STRUCT()
+	}
+
+	res := []Change{}
+	for _, field := range p.flagFields(va.Type()) {
+		before, after := diffField(va, field), diffField(vb, field)
+		if !reflect.DeepEqual(before, after) {
+			res = append(res, Change{Field: field.path, Flag: field.name, Old: before, New: after})
+		}
+	}
+	return res, nil
+}
+
+// it returns the value of the field, it is the zero value if a nested struct
+// pointer is nil.
+func diffField(v reflect.Value, field flagField) any {
+	val, err := v.FieldByIndexErr(field.index)
+	if err != nil {
+		val = reflect.Zero(field.Type)
+	}
+	return val.Interface()
+}
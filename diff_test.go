@@ -0,0 +1,66 @@
+package flags_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type diffed struct {
+	Port    int
+	Host    string
+	Verbose bool
+	Tags    []string
+	Db      *struct {
+		Host string
+	} `prefix:"db"`
+}
+
+func TestReportIsSet(t *testing.T) {
+	val := &diffed{Port: 80}
+	report, err := flags.LoadReport(strings.Fields("--host 'localhost' --db.host 'db'"), val)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	for field, need := range map[string]bool{"host": true, "Host": true, "db.host": true, "Db.Host": true, "port": false, "Tags": false, "unknown": false} {
+		if got := report.IsSet(field); got != need {
+			t.Fatalf("got IsSet(%q) %v, expected %v", field, got, need)
+		}
+	}
+	if set, need := report.SetFlags(), []string{"host", "db.host"}; !slices.Equal(set, need) {
+		t.Fatalf("got set flags %v, expected %v", set, need)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := diffed{Port: 80, Tags: []string{"a"}}
+	b := &diffed{Port: 8080, Verbose: true, Tags: []string{"a"}}
+	b.Db = &struct{ Host string }{Host: "db"}
+
+	changes, err := flags.Diff(a, b)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	got := []string{}
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	need := []string{"--port: 80 -> 8080", "--verbose: false -> true", `--db.host: "" -> "db"`}
+	if !slices.Equal(got, need) {
+		t.Fatalf("got changes %q, expected %q", got, need)
+	}
+	if changes[0].Field != "Port" || changes[0].Old != 80 || changes[0].New != 8080 {
+		t.Fatalf("got change %+v", changes[0])
+	}
+
+	if changes, err := flags.Diff(a, a); err != nil || len(changes) != 0 {
+		t.Fatalf("got changes %v and error %v", changes, err)
+	}
+	if _, err := flags.Diff(a, struct{ Port int }{}); !errors.Is(err, flags.DIFFERENT_TYPES()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.DIFFERENT_TYPES(), err)
+	}
+}
@@ -0,0 +1,173 @@
+package flags
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vandi37/flags/internal/usage"
+)
+
+// it is the description of a flag for help text and documentation.
+type flagDoc struct {
+	// The full flag name.
+	name string
+	// The path of the nested struct with the flag.
+	group string
+	// The shortcut or zero.
+	short rune
+	// Other full names of the flag.
+	aliases []string
+	typ     string
+	def     string
+	usage   string
+	env     string
+	enum    []string
+	// The `complete` tag ("file" or "dir").
+	complete string
+
+	required bool
+}
+
+// it returns descriptions of all flags of a struct.
+func (p *Parser) flagDocs(v any) ([]flagDoc, error) {
+	p, err := p.withTags(v)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, IS_NOT_A_STRUCT()
+	}
+
+	docs := []flagDoc{}
+	for _, field := range p.flagFields(rv.Type()) {
+		doc := flagDoc{
+			name:     field.name,
+			group:    field.group,
+			typ:      typeName(field.Type),
+			usage:    field.Tag.Get("usage"),
+			env:      p.envVariable(field.StructField, field.name),
+			enum:     enumValues(field.StructField),
+			required: isRequired(field.StructField),
+			complete: field.Tag.Get("complete"),
+		}
+		if s, ok := p.shortcut(field.name); ok {
+			doc.short = s
+		}
+		for _, alias := range slices.Sorted(maps.Keys(p.Aliases)) {
+			if p.Aliases[alias] == field.name {
+				doc.aliases = append(doc.aliases, alias)
+			}
+		}
+		if val, err := rv.FieldByIndexErr(field.index); err == nil {
+			doc.def, _ = formatValue(val)
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// it groups descriptions by nested structs, top level flags are first.
+func groupDocs(docs []flagDoc) [][]flagDoc {
+	return usage.Group(docs, func(doc flagDoc) string { return doc.group })
+}
+
+// it returns the description for help text.
+func (d flagDoc) info() FlagInfo {
+	return FlagInfo{
+		Name:     d.name,
+		Group:    d.group,
+		Short:    d.short,
+		Aliases:  d.aliases,
+		Type:     d.typ,
+		Default:  d.def,
+		Usage:    d.usage,
+		Env:      d.env,
+		Enum:     d.enum,
+		Required: d.required,
+	}
+}
+
+// it returns the name of the type for help text.
+func typeName(t reflect.Type) string {
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return "duration"
+	case t == timeType:
+		return "time"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return ""
+	case reflect.Pointer:
+		return typeName(t.Elem())
+	case reflect.Slice, reflect.Array:
+		elem := typeName(t.Elem())
+		if elem == "" {
+			elem = "bool"
+		}
+		return elem + "..."
+	case reflect.Interface:
+		return "value"
+	case reflect.UnsafePointer:
+		return "pointer"
+	default:
+		return t.Kind().String()
+	}
+}
+
+// it formats a non zero value the same way as it is written in the command line.
+func formatValue(v reflect.Value) (string, bool) {
+	if !v.IsValid() || v.IsZero() {
+		return "", false
+	}
+
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		return time.Duration(v.Int()).String(), true
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), true
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return formatValue(v.Elem())
+	case reflect.String:
+		return "'" + v.String() + "'", true
+	case reflect.Slice, reflect.Array:
+		vals := []string{}
+		for i := 0; i < v.Len(); i++ {
+			if val, ok := formatValue(v.Index(i)); ok {
+				vals = append(vals, val)
+			} else {
+				vals = append(vals, fmt.Sprint(v.Index(i).Interface()))
+			}
+		}
+		return strings.Join(vals, " "), true
+	case reflect.UnsafePointer:
+		return fmt.Sprint(v.Pointer()), true
+	default:
+		return fmt.Sprint(v.Interface()), true
+	}
+}
+
+// it returns the shortcut for the flag.
+func (p *Parser) shortcut(name string) (rune, bool) {
+	found := false
+	var res rune
+	for s, fl := range p.Shortcuts {
+		if fl == name && (!found || s < res) {
+			res = s
+			found = true
+		}
+	}
+	return res, found
+}
@@ -0,0 +1,57 @@
+package flags_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vandi37/flags"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("got an error: %v", err)
+		}
+	}
+
+	need, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if !bytes.Equal(need, got) {
+		t.Fatalf("got different output:\n%s\nexpected:\n%s", got, need)
+	}
+}
+
+func docParser() (*flags.Parser, *withUsage) {
+	return &flags.Parser{Shortcuts: map[rune]string{'p': "port", 'v': "verbose"}},
+		&withUsage{Port: 3700, Timeout: time.Second, Tags: []string{"a", "b"}}
+}
+
+func TestMan(t *testing.T) {
+	p, val := docParser()
+
+	var b bytes.Buffer
+	if err := p.WriteMan(&b, "app", "Runs the app server.", val); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	golden(t, "app.1", b.Bytes())
+}
+
+func TestMarkdown(t *testing.T) {
+	p, val := docParser()
+
+	var b bytes.Buffer
+	if err := p.WriteMarkdown(&b, "app", "Runs the app server.", val); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	golden(t, "app.md", b.Bytes())
+}
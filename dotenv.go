@@ -0,0 +1,154 @@
+package flags
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// it reads a dotenv file into a map of environment variables to their values.
+//
+// Lines may start with "export". Lines starting with "#" are comments, also
+// everything after " #" in a value without brackets is a comment.
+//
+// Values in double brackets (") may have escapes (\n, \t, \", \\). Values in
+// double brackets and without brackets expand `${VAR}` using keys from the
+// file above and then environment variables. Values in single brackets (')
+// are used as is.
+//
+// It returns a map in the same form as [Parse], but the keys are variables
+// and every variable has one value as it is, without brackets. Insert it as a
+// source with [Source.Env], so the variables are bound to fields the same
+// way as environment variables (see [Parser.InsertSources]).
+//
+// Example, prefix "APP", for a struct with field `MaxConns` and nested
+// struct field `Db` with prefix "db" and field `Host`:
+// `APP_MAX_CONNS=10` is the same as `--max_conns 10` and
+// `APP_DB_HOST=localhost` is the same as `--db.host 'localhost'`
+func ReadDotenv(r io.Reader) (map[string][]string, error) {
+	return new(Parser).ReadDotenv(r)
+}
+
+// it reads a dotenv file using the parser settings.
+//
+// It works the same way as [ReadDotenv], but environment variables are looked
+// up with [Parser.LookupEnv].
+func (p *Parser) ReadDotenv(r io.Reader) (map[string][]string, error) {
+	vars := make(map[string]string)
+	errs := []error{}
+
+	lookup := func(key string) string {
+		if val, ok := vars[key]; ok {
+			return val
+		}
+		val, _ := p.lookupEnv(key)
+		return val
+	}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if words := strings.Fields(line); len(words) > 1 && words[0] == "export" {
+			line = strings.TrimSpace(strings.TrimPrefix(line, "export"))
+		}
+
+		key, val, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			errs = append(errs, BAD_LINE(n, line, "expected KEY=value"))
+			continue
+		}
+
+		raw, err := dotenvValue(strings.TrimSpace(val), lookup)
+		if err != "" {
+			errs = append(errs, BAD_LINE(n, line, err))
+			continue
+		}
+		vars[key] = raw
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, mega("got some errors", errs)
+	}
+
+	res := make(map[string][]string)
+	for key, val := range vars {
+		res[key] = []string{val}
+	}
+	return res, nil
+}
+
+// it returns the value without brackets and a problem description.
+func dotenvValue(val string, lookup func(string) string) (string, string) {
+	if strings.HasPrefix(val, "'") {
+		end := strings.Index(val[1:], "'")
+		if end < 0 {
+			return "", "value isn't closed"
+		}
+		if rest := strings.TrimSpace(val[end+2:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", "unexpected text after value"
+		}
+		return val[1 : end+1], ""
+	}
+
+	if strings.HasPrefix(val, `"`) {
+		var b strings.Builder
+		for i := 1; i < len(val); i++ {
+			switch c := val[i]; c {
+			case '\\':
+				if i+1 >= len(val) {
+					return "", "value isn't closed"
+				}
+				i++
+				switch val[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				case 'r':
+					b.WriteByte('\r')
+				default:
+					b.WriteByte(val[i])
+				}
+			case '"':
+				if rest := strings.TrimSpace(val[i+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+					return "", "unexpected text after value"
+				}
+				return expand(b.String(), lookup), ""
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", "value isn't closed"
+	}
+
+	if i := strings.Index(val, " #"); i >= 0 {
+		val = strings.TrimSpace(val[:i])
+	}
+	return expand(val, lookup), ""
+}
+
+// it replaces `${VAR}` with values of variables.
+func expand(s string, lookup func(string) string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			break
+		}
+		b.WriteString(s[:start])
+		b.WriteString(lookup(s[start+2 : start+end]))
+		s = s[start+end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package flags
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/vandi37/flags/internal/convert"
+)
+
+// it returns the values of the environment variable bound to a field.
+//
+// The variable is taken from the `env` tag, or, if the parser has an
+// [Parser.EnvPrefix], it is built from the flag name. Empty variables are
+// treated as unset. It also returns the name of the variable.
+func (p *Parser) env(field reflect.StructField, fieldName string) ([]string, string, bool) {
+	name := p.envVariable(field, fieldName)
+	if name == "" {
+		return nil, "", false
+	}
+
+	val, ok := p.lookupEnv(name)
+	if !ok || val == "" {
+		return nil, name, false
+	}
+
+	return envArgs(val, field.Type, p.envSeparator()), name, true
+}
+
+// it returns the name of the environment variable bound to a field, or an
+// empty string.
+func (p *Parser) envVariable(field reflect.StructField, fieldName string) string {
+	return convert.EnvVariable(field.Tag.Get("env"), p.EnvPrefix, fieldName)
+}
+
+// it binds variables of a source with [Source.Env] to flags of the fields of
+// a struct.
+//
+// Variables are bound the same way as environment variables: with the `env`
+// tag, or with the flag name and the [Parser.EnvPrefix]. Without the prefix
+// the variable is the upper cased flag name, dots and dashes are "_". Values
+// of a variable are joined by [Parser.EnvSeparator], empty values and
+// variables without a field are skipped.
+func (p *Parser) envFlags(vars map[string][]string, t reflect.Type) map[string][]string {
+	res := make(map[string][]string)
+	for _, field := range p.flagFields(t) {
+		name := p.envVariable(field.StructField, field.name)
+		if name == "" && field.Tag.Get("env") != "-" {
+			name = strings.TrimPrefix(envName("", field.name), "_")
+		}
+		if val := strings.Join(vars[name], p.envSeparator()); name != "" && val != "" {
+			res[field.name] = envArgs(val, field.Type, p.envSeparator())
+		}
+	}
+	return res
+}
+
+// it builds an environment variable name from a prefix and a flag name (see [convert.EnvName]).
+var envName = convert.EnvName
+
+// it converts an environment value to the same form as command-line values.
+//
+// Values for slices and arrays are split by the separator. Values for strings
+// are quoted, because environment values never have brackets.
+func envArgs(val string, t reflect.Type, sep string) []string {
+	t = indirectType(t)
+
+	args := []string{val}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		args = strings.Split(val, sep)
+		t = indirectType(t.Elem())
+	}
+
+	for i, arg := range args {
+		if t.Kind() == reflect.String || (t.Kind() == reflect.Interface && defaultConvert(arg) == nil) {
+			args[i] = quoteString(arg)
+		}
+	}
+
+	return args
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,82 @@
+package flags_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type withEnv struct {
+	Port  int      `flag:"port" env:"APP_PORT"`
+	Host  string   `flag:"host" env:"APP_HOST"`
+	Tags  []string `flag:"tags" env:"APP_TAGS"`
+	Debug bool     `flag:"debug" env:"APP_DEBUG"`
+}
+
+func lookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		val, ok := env[key]
+		return val, ok
+	}
+}
+
+func TestEnv(t *testing.T) {
+	p := &flags.Parser{LookupEnv: lookup(map[string]string{
+		"APP_PORT":  "8080",
+		"APP_HOST":  "localhost",
+		"APP_TAGS":  "a,b,c",
+		"APP_DEBUG": "true",
+	})}
+
+	val := new(withEnv)
+	err := p.Load(strings.Fields("--port 3700"), val)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	need := withEnv{3700, "localhost", []string{"a", "b", "c"}, true}
+	if val.Port != need.Port || val.Host != need.Host || !slices.Equal(val.Tags, need.Tags) || val.Debug != need.Debug {
+		t.Fatalf("got structure %+v, expected %+v", val, need)
+	}
+}
+
+type withEnvPrefix struct {
+	MaxConns int
+	Name     string `env:"-"`
+	Inside   inside
+}
+
+func TestEnvPrefix(t *testing.T) {
+	p := &flags.Parser{
+		EnvPrefix: "APP",
+		LookupEnv: lookup(map[string]string{
+			"APP_MAX_CONNS": "10",
+			"APP_NAME":      "ignored",
+			"APP_NUM":       "37",
+			"APP_STR":       "",
+		}),
+		EnvSeparator: ";",
+	}
+
+	val := new(withEnvPrefix)
+	err := p.Load([]string{}, val)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	if val.MaxConns != 10 || val.Name != "" || val.Inside.Base.Num != 37 || val.Inside.Str != "" {
+		t.Fatalf("got structure %+v", val)
+	}
+}
+
+func TestEnvError(t *testing.T) {
+	p := &flags.Parser{LookupEnv: lookup(map[string]string{"APP_PORT": "port"})}
+
+	err := p.Insert(map[string][]string{}, new(withEnv))
+	if !errors.Is(err, flags.CANT_CONVERT()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.CANT_CONVERT(), err)
+	}
+}
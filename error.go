@@ -2,50 +2,122 @@ package flags
 
 import (
 	"fmt"
-)
 
-type flagError struct {
-	name string
-	real string
-}
+	"github.com/vandi37/flags/internal/convert"
+)
 
-func (e *flagError) Error() string {
-	return e.real
-}
+type flagError = convert.Error
 
 func err(name string, real string) func(args ...any) error {
 	return func(args ...any) error {
-		return &flagError{name: name, real: fmt.Sprintf(real, args...)}
+		return &flagError{Name: name, Message: fmt.Sprintf(real, args...)}
 	}
 }
 
-func (e *flagError) Is(target error) bool {
-	t, ok := target.(*flagError)
-	if !ok {
-		return e.name == target.Error()
-	}
+// it reports whether the target is an error with the name (see [convert.Error.Is]).
+func isFlagError(name string, target error) bool {
+	return convert.IsError(name, target)
+}
+
+// it is the error for a value, that can't be converted to the type of a field.
+//
+// It is the same as the [CANT_CONVERT] error for [errors.Is], values for
+// empty interfaces are also the same as the [CANT_DEFAULT_CONVERT] error.
+//
+// Fields: Flag (the flag name without "--"), Field (the path of the field,
+// nested struct fields are separated by dots), Value (the value from the
+// arguments), TargetType (the type of the field or of the element for slices
+// and arrays), Cause (the error from converting, it may be nil) and Position
+// (the index of the value in the arguments, or -1).
+type ConversionError = convert.ConversionError
+
+var conversionError = convert.Conversion
+
+// it is the error for a flag with more values than its field takes.
+//
+// It is the same as the [TOO_MANY_ARGUMENTS] error for [errors.Is].
+//
+// Fields: Flag, Field, Got (the number of values), Max (the maximum number of
+// values) and Position (the index of the flag in the arguments, or -1).
+type ArityError = convert.ArityError
+
+var arityError = convert.Arity
+
+// it is the error for a value, that isn't in the `enum` tag of a field.
+//
+// It is the same as the [NOT_ALLOWED] error for [errors.Is].
+//
+// Fields: Flag, Field, Value, Allowed (the allowed values) and Position (the
+// index of the value in the arguments, or -1).
+type NotAllowedError = convert.NotAllowedError
 
-	return t.name == e.name
+// it is an error of a field, that isn't a [ConversionError], an [ArityError]
+// or a [NotAllowedError] (e.g. [REQUIRED_FLAG]).
+//
+// It is the same as the wrapped error for [errors.Is].
+//
+// Fields: Flag, Field, Position (the index of the flag in the arguments, or
+// -1) and Err.
+type FieldError = convert.FieldError
+
+// it is an error of an argument (e.g. [TWICE_FLAG] or [ARGUMENT_NOT_NEED]).
+//
+// It is the same as the wrapped error for [errors.Is].
+type ArgumentError struct {
+	// The index of the argument.
+	Position int
+	Err      error
 }
 
-type megaError struct {
-	name string
-	errs []error
+func (e *ArgumentError) Error() string {
+	return e.Err.Error()
 }
 
-func (e *megaError) Error() string {
-	res := e.name + ":"
-	for _, err := range e.errs {
-		res += "\n	- " + err.Error()
-	}
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
 
-	return res
+// it is the error for a flag, that isn't bound to any field.
+//
+// It is the same as the [UNKNOWN_FLAG] error for [errors.Is].
+type UnknownFlagError struct {
+	// The flag name without "--".
+	Flag string
+	// Similar known flags and shortcuts with "--" or "-", the closest are first.
+	Suggestions []string
+	// The index of the flag in the arguments, or -1 if it isn't from the arguments.
+	Position int
 }
 
-func (e *megaError) Unwrap() []error {
-	return e.errs
+func (e *UnknownFlagError) Error() string {
+	return fmt.Sprintf("unknown flag --%s", e.Flag) + didYouMean(e.Suggestions)
 }
 
-func mega(name string, errs []error) error {
-	return &megaError{name: name, errs: errs}
+func (e *UnknownFlagError) Is(target error) bool {
+	return isFlagError("unknown flag", target)
 }
+
+// it is the error for a shortcut, that doesn't exist.
+//
+// It is the same as the [WRONG_SHORTCUT] error for [errors.Is].
+type UnknownShortcutError struct {
+	Shortcut rune
+	// Similar known shortcuts and flags with "-" or "--".
+	Suggestions []string
+	// The index of the argument with the shortcut.
+	Position int
+	// The index of the shortcut in the argument (in runes, "-" is 0).
+	Offset int
+}
+
+func (e *UnknownShortcutError) Error() string {
+	return fmt.Sprintf("shortcut '%c' does not exist", e.Shortcut) + didYouMean(e.Suggestions)
+}
+
+func (e *UnknownShortcutError) Is(target error) bool {
+	return isFlagError("wrong shortcut", target)
+}
+
+type megaError = convert.MultiError
+
+var mega = convert.Join
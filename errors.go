@@ -3,6 +3,10 @@ package flags
 // It is all errors, that are used in this package
 //
 // You can compare the errors using errors.Is(your_error, ERROR_REF()) (The error reference could be without values, it won't change the result)
+//
+// Some errors are also typed ([ConversionError], [ArityError], [FieldError],
+// [ArgumentError], [UnknownFlagError], [UnknownShortcutError]), you can get
+// the field, the flag and the position in the arguments using errors.As.
 var (
 	// need a string
 	TWICE_FLAG = err("twice flag", "flag '%s' is used twice in the arguments")
@@ -20,4 +24,38 @@ var (
 	UNSUPPORTABLE_TYPE = err("unsupportable type", "type %s isn't supported")
 	// need a value
 	CANT_DEFAULT_CONVERT = err("cant default convert", "cant do default converting for val %v")
+	// need a string and an error
+	CANT_READ_CONFIG = err("cant read config", "cant read config file %s: %v")
+	// need a string, a string, a value and an error
+	BAD_CONFIG = err("bad config", "config file %s: %s: bad value %v: %v")
+	// need an int, a string and a string
+	BAD_LINE = err("bad line", "line %d '%s': %s")
+	// need a string, an int and a value
+	BAD_RESPONSE_FILE = err("bad response file", "%s:%d: %v")
+	// need a string
+	REQUIRED_FLAG = err("required flag", "flag %s is required")
+	// need a string, a string and a string
+	NOT_ALLOWED = err("not allowed", "value '%s' isn't allowed for flag %s, allowed values: %s")
+	HELP        = err("help", "help is requested")
+	COMPLETE    = err("complete", "completion is requested")
+	// need a string, a string and a string
+	UNKNOWN_COMMAND = err("unknown command", "unknown command '%s' for %s%s")
+	// need a string
+	UNKNOWN_FLAG = err("unknown flag", "unknown flag --%s")
+	// need a string
+	NO_COMMAND = err("no command", "command %s needs a subcommand")
+	// need a string and a string
+	BAD_SHORTCUT = err("bad shortcut", "shortcut '%s' of field %s isn't a single character")
+	// need a char, a string and a string
+	SHORTCUT_CONFLICT = err("shortcut conflict", "shortcut '%c' is used for flags %s and %s")
+	// need a string, a string and a string
+	ALIAS_CONFLICT = err("alias conflict", "alias %s is used for flags %s and %s")
+	// need a string and a string
+	AMBIGUOUS_FLAG = err("ambiguous flag", "flag --%s is ambiguous, candidates: %s")
+	// need a string and a string
+	NAME_COLLISION = err("name collision", "flags %s and %s are the same after normalization")
+	// need a string
+	NO_FLAG = err("no flag", "flag %s isn't set")
+	// need a value and a value
+	DIFFERENT_TYPES = err("different types", "types %v and %v are different")
 )
@@ -2,7 +2,9 @@ package flags_test
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/vandi37/flags"
@@ -74,19 +76,19 @@ func ExampleParse() {
 		panic(err)
 	}
 
-	for flag, vals := range f {
+	for _, flag := range slices.Sorted(maps.Keys(f)) {
 		fmt.Println(flag)
 
-		for _, val := range vals {
+		for _, val := range f[flag] {
 			fmt.Println("-", val)
 		}
 	}
 
 	// Output:
-	// port
-	// - 3700
 	// host
 	// - 'localhost'
+	// port
+	// - 3700
 }
 
 func ExampleParseWithShortcuts() {
@@ -95,19 +97,19 @@ func ExampleParseWithShortcuts() {
 		panic(err)
 	}
 
-	for flag, vals := range f {
+	for _, flag := range slices.Sorted(maps.Keys(f)) {
 		fmt.Println(flag)
 
-		for _, val := range vals {
+		for _, val := range f[flag] {
 			fmt.Println("-", val)
 		}
 	}
 
 	// Output:
-	// port
-	// - 3700
 	// host
 	// - 'localhost'
+	// port
+	// - 3700
 }
 
 func ExampleInsert() {
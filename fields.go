@@ -0,0 +1,183 @@
+package flags
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vandi37/flags/internal/convert"
+	"github.com/vandi37/flags/internal/tags"
+)
+
+// it is a struct field, that is bound to a flag.
+type flagField struct {
+	reflect.StructField
+	// The index sequence for [reflect.Value.FieldByIndex].
+	index []int
+	// The path of the field, nested struct fields are separated by dots (e.g. "Db.Host").
+	path string
+	// The full flag name with prefixes.
+	name string
+	// The prefix of the flag name.
+	prefix string
+	// The path of the nested struct with the field, it is empty for top level fields.
+	group string
+	// The values from the `enum` tag.
+	enum []string
+	// It is true for fields with the `required:"true"` tag.
+	required bool
+	// It is true for [time.Time] fields.
+	isTime bool
+}
+
+// it is the key of cached fields of a struct type.
+type fieldsKey struct {
+	t reflect.Type
+	// The index of the naming strategy in builtinNamings.
+	naming int
+}
+
+// The fields of struct types, the key is [fieldsKey] and the value is []flagField.
+var fieldsCache sync.Map
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// it returns all fields of a struct type bound to flags in the same order and
+// with the same names as [Parser.Insert] uses.
+//
+// The fields are built once for every struct type and naming strategy, if the
+// strategy isn't one of the package strategies (like [SnakeCase]), they are
+// built on every call. Custom strategies aren't cached by their function
+// pointers, because closures made by the same function literal have the same
+// pointer, but may name fields differently. The result mustn't be changed.
+func (p *Parser) flagFields(t reflect.Type) []flagField {
+	t = indirectType(t)
+	naming, ok := p.namingIndex()
+	if !ok {
+		return p.buildFlagFields(t)
+	}
+
+	key := fieldsKey{t: t, naming: naming}
+	if fields, ok := fieldsCache.Load(key); ok {
+		return fields.([]flagField)
+	}
+	fields, _ := fieldsCache.LoadOrStore(key, p.buildFlagFields(t))
+	return fields.([]flagField)
+}
+
+func (p *Parser) buildFlagFields(t reflect.Type) []flagField {
+	return appendFlagFields(nil, t, nil, "", "", "", structNaming(t, "", p.naming()))
+}
+
+func appendFlagFields(res []flagField, t reflect.Type, index []int, prefix string, path string, group string, naming NameFunc) []flagField {
+	if t.Kind() != reflect.Struct {
+		return res
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		fieldType := t.Field(i)
+		fieldName := fieldFlagName(fieldType, naming)
+		if fieldName == "-" || !fieldType.IsExported() {
+			continue
+		}
+
+		fieldIndex := append(append([]int{}, index...), i)
+		fieldPath := path + fieldType.Name
+
+		if isNested(fieldType.Type) {
+			res = appendFlagFields(res, indirectType(fieldType.Type), fieldIndex, nestedPrefix(prefix, fieldType), fieldPath+".", fieldPath, fieldNaming(fieldType, naming))
+			continue
+		}
+
+		res = append(res, flagField{
+			StructField: fieldType,
+			index:       fieldIndex,
+			path:        fieldPath,
+			name:        prefix + fieldName,
+			prefix:      prefix,
+			group:       group,
+			enum:        enumValues(fieldType),
+			required:    isRequired(fieldType),
+			isTime:      fieldType.Type == timeType,
+		})
+	}
+
+	return res
+}
+
+// it reports whether the fields of the type are inserted as nested struct fields.
+func isNested(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// it reports whether the field is tagged with `required:"true"`.
+func isRequired(field reflect.StructField) bool {
+	required, _ := strconv.ParseBool(field.Tag.Get("required"))
+	return required
+}
+
+// it returns the values from the `enum` tag.
+func enumValues(field reflect.StructField) []string {
+	enum := field.Tag.Get("enum")
+	if enum == "" {
+		return nil
+	}
+	return strings.Split(enum, ",")
+}
+
+// it checks, that all values are allowed by the `enum` tag.
+var checkEnum = convert.CheckEnum
+
+// it returns the field by the index sequence, nil pointers to nested structs
+// are allocated. It returns the zero value, if a pointer can't be allocated.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// it returns the flag name of the field without the prefix, it is taken from
+// the `flag` tag (before a comma) or from the field name converted with the
+// naming strategy, it is "-" for ignored fields.
+func fieldFlagName(field reflect.StructField, naming NameFunc) string {
+	name, _, _ := strings.Cut(field.Tag.Get("flag"), ",")
+	if name == "" {
+		name = naming(field.Name)
+	}
+	return name
+}
+
+// it returns the naming strategy for fields of the nested struct in the field
+// (see [structNaming]).
+func fieldNaming(field reflect.StructField, naming NameFunc) NameFunc {
+	return structNaming(field.Type, field.Tag.Get("naming"), naming)
+}
+
+// it returns the shortcuts of the field (see [tags.Shortcuts]).
+func fieldShortcuts(field flagField) ([]rune, error) {
+	res, bad := tags.Shortcuts(field.Tag)
+	if bad != "" {
+		return nil, BAD_SHORTCUT(bad, field.path)
+	}
+	return res, nil
+}
+
+// it returns the full names of aliases from the `alias` tag (see [tags.Aliases]).
+func fieldAliases(field flagField) []string {
+	return tags.Aliases(field.Tag, field.prefix)
+}
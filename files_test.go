@@ -0,0 +1,149 @@
+package flags_test
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type withPrefix struct {
+	Name  string
+	Debug bool
+	Db    struct {
+		Host  string
+		Ports []int
+	} `prefix:"db"`
+}
+
+func TestINI(t *testing.T) {
+	f, err := flags.ReadINI(strings.NewReader(`
+; comment
+name = 'app'
+debug
+
+[db]
+# other comment
+host = "localhost"
+ports = 5432
+ports = 5433
+`))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	need := map[string][]string{
+		"name":     {"'app'"},
+		"debug":    {},
+		"db.host":  {`"localhost"`},
+		"db.ports": {"5432", "5433"},
+	}
+	if !maps.EqualFunc(need, f, func(v1, v2 []string) bool { return slices.Equal(v1, v2) }) {
+		t.Fatalf("got different maps: expected %v, got %v", need, f)
+	}
+
+	val := new(withPrefix)
+	if err := flags.Insert(f, val); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if val.Name != "app" || !val.Debug || val.Db.Host != "localhost" || !slices.Equal(val.Db.Ports, []int{5432, 5433}) {
+		t.Fatalf("got structure %+v", val)
+	}
+}
+
+type dotenvConfig struct {
+	Name string
+	Dir  string
+	Port int
+	Log  string
+	Tags []string
+	Db   struct {
+		Host string
+	} `prefix:"db"`
+	Token string `env:"SECRET_TOKEN"`
+}
+
+func TestDotenv(t *testing.T) {
+	p := &flags.Parser{
+		EnvPrefix: "APP",
+		LookupEnv: lookup(map[string]string{"HOME": "/home/user"}),
+	}
+
+	f, err := p.ReadDotenv(strings.NewReader(`
+# comment
+export APP_NAME='${HOME} as is'
+APP_DIR="${HOME}/app\t# not a comment"
+APP_PORT=3700 # comment
+APP_LOG=${APP_DIR}/log
+export	APP_TAGS=a,b
+APP_DB_HOST=localhost
+SECRET_TOKEN=secret
+OTHER=value
+`))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	need := map[string][]string{
+		"APP_NAME":     {"${HOME} as is"},
+		"APP_DIR":      {"/home/user/app\t# not a comment"},
+		"APP_PORT":     {"3700"},
+		"APP_LOG":      {"/home/user/app\t# not a comment/log"},
+		"APP_TAGS":     {"a,b"},
+		"APP_DB_HOST":  {"localhost"},
+		"SECRET_TOKEN": {"secret"},
+		"OTHER":        {"value"},
+	}
+	if !maps.EqualFunc(need, f, func(v1, v2 []string) bool { return slices.Equal(v1, v2) }) {
+		t.Fatalf("got different maps: expected %v, got %v", need, f)
+	}
+
+	val := new(dotenvConfig)
+	report, err := p.InsertSources(val, flags.Source{Name: ".env", Flags: f, Env: true})
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if val.Name != "${HOME} as is" || val.Port != 3700 || val.Log != "/home/user/app\t# not a comment/log" ||
+		!slices.Equal(val.Tags, []string{"a", "b"}) || val.Db.Host != "localhost" || val.Token != "secret" {
+		t.Fatalf("got structure %+v", val)
+	}
+	if source, _ := report.Origin("db.host"); source != ".env" {
+		t.Fatalf("got source %q, expected %q", source, ".env")
+	}
+
+	f, err = flags.ReadDotenv(strings.NewReader("NAME=app\nDB_HOST=localhost"))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	val = new(dotenvConfig)
+	if _, err := new(flags.Parser).InsertSources(val, flags.Source{Name: ".env", Flags: f, Env: true}); err != nil || val.Name != "app" || val.Db.Host != "localhost" {
+		t.Fatalf("got structure %+v and error %v", val, err)
+	}
+}
+
+func TestFilesError(t *testing.T) {
+	cases := []struct {
+		name string
+		read func() error
+	}{
+		{"ini section", func() error { _, err := flags.ReadINI(strings.NewReader("[db")); return err }},
+		{"ini key", func() error { _, err := flags.ReadINI(strings.NewReader("= value")); return err }},
+		{"dotenv line", func() error { _, err := flags.ReadDotenv(strings.NewReader("KEY")); return err }},
+		{"dotenv bracket", func() error {
+			_, err := flags.ReadDotenv(strings.NewReader(`KEY="value`))
+			return err
+		}},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("#%d %s", i, tc.name), func(t *testing.T) {
+			if err := tc.read(); !errors.Is(err, flags.BAD_LINE()) {
+				t.Fatalf("got different errors expected %v, got %v", flags.BAD_LINE(), err)
+			}
+		})
+	}
+}
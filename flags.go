@@ -47,6 +47,11 @@
 //
 // `-fo value_for_flag value_for_other_flag also_value_for_other_flag ...`
 //
+// 5. A negative number after a flag is a value, if it doesn't start with a shortcut.
+//
+// Example:
+// `--offset -5`
+//
 // # Converting Flags to Types
 //
 // Flags may be inserted into a structure, here are the rules:
@@ -116,7 +121,10 @@
 package flags
 
 import (
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // it parses a slice of strings into a map of flag names to their values.
@@ -148,51 +156,141 @@ func Parse(args []string) (map[string][]string, error) {
 //
 // Full flag forming rules are in readme
 func ParseWithShortcuts(args []string, shortcuts map[rune]string) (map[string][]string, error) {
+	p := &Parser{Shortcuts: shortcuts}
+	return p.Parse(args)
+}
+
+// it parses a slice of strings into a map of flag names to their values
+// using the parser settings.
+//
+// It works the same way as [ParseWithShortcuts] with [Parser.Shortcuts].
+// If [Parser.ResponseFiles] is true, `@path` arguments are expanded first.
+// Aliases from [Parser.Aliases] and abbreviations (see [Parser.Abbreviations])
+// are replaced with flag names, with [SingleDashNames] `-name` is the same as
+// `--name`.
+func (p *Parser) Parse(args []string) (map[string][]string, error) {
+	res, _, err := p.parse(args)
+	return res, err
+}
+
+// it is the position of a flag and its values in the arguments.
+type flagPosition struct {
+	flag   int
+	values []int
+}
+
+// it maps flag names to their positions in the arguments.
+type positions map[string]*flagPosition
+
+// it returns the index of the flag in the arguments, or -1.
+func (pos positions) flag(name string) int {
+	if fp, ok := pos[name]; ok {
+		return fp.flag
+	}
+	return -1
+}
+
+// it returns the index of the value of the flag in the arguments, or -1.
+func (pos positions) value(name string, args []string, value string) int {
+	fp, ok := pos[name]
+	if !ok {
+		return -1
+	}
+	for i, arg := range args {
+		if arg == value && i < len(fp.values) {
+			return fp.values[i]
+		}
+	}
+	return fp.flag
+}
+
+// it replaces positions in cut arguments with positions in the full
+// arguments, index has the full position of every cut argument.
+func (pos positions) remap(index []int) {
+	for _, fp := range pos {
+		fp.flag = remapPosition(fp.flag, index)
+		for i := range fp.values {
+			fp.values[i] = remapPosition(fp.values[i], index)
+		}
+	}
+}
+
+func remapPosition(i int, index []int) int {
+	if i >= 0 && i < len(index) {
+		return index[i]
+	}
+	return i
+}
+
+func (p *Parser) parse(args []string) (map[string][]string, positions, error) {
+	if p.ResponseFiles {
+		var err error
+		if args, err = ExpandResponseFiles(args); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	shortcuts := p.Shortcuts
 	res := make(map[string][]string)
+	pos := make(positions)
 	errs := []error{}
 
 	currentFlags := []string{}
-	for _, el := range args {
-		if strings.HasPrefix(el, "--") {
-			el = strings.TrimPrefix(el, "--")
-			if _, ok := res[el]; ok {
-				errs = append(errs, TWICE_FLAG(el))
-				continue
-			}
+	flag := func(i int, name string) {
+		name, err := p.resolve(name)
+		if err != nil {
+			errs = append(errs, &ArgumentError{Position: i, Err: err})
+			currentFlags = []string{}
+			return
+		}
+		if _, ok := res[name]; ok {
+			errs = append(errs, &ArgumentError{Position: i, Err: TWICE_FLAG(name)})
+			return
+		}
+
+		res[name] = []string{}
+		pos[name] = &flagPosition{flag: i}
+		currentFlags = []string{name}
+	}
+
+	value := func(i int, el string) {
+		if len(currentFlags) <= 0 {
+			errs = append(errs, &ArgumentError{Position: i, Err: ARGUMENT_NOT_NEED(el)})
+			return
+		}
+
+		res[currentFlags[0]] = append(res[currentFlags[0]], el)
+		pos[currentFlags[0]].values = append(pos[currentFlags[0]].values, i)
+		if len(currentFlags) > 1 {
+			currentFlags = currentFlags[1:]
+		}
+	}
 
-			res[el] = []string{}
-			currentFlags = []string{el}
+	for i, el := range args {
+		if len(currentFlags) > 0 && isNegativeNumber(el, shortcuts) {
+			value(i, el)
+		} else if strings.HasPrefix(el, "--") {
+			flag(i, strings.TrimPrefix(el, "--"))
+		} else if strings.HasPrefix(el, "-") && p.SingleDash == SingleDashNames && utf8.RuneCountInString(el) > 2 {
+			flag(i, strings.TrimPrefix(el, "-"))
 		} else if strings.HasPrefix(el, "-") {
 			el = strings.TrimPrefix(el, "-")
 			currentFlags = []string{}
 
-			for _, s := range el {
+			for j, s := range []rune(el) {
 				var ok bool
 				var fl string
 				if fl, ok = shortcuts[s]; !ok {
-					errs = append(errs, WRONG_SHORTCUT(s))
+					errs = append(errs, &UnknownShortcutError{Shortcut: s, Suggestions: p.suggestShortcut(s, el), Position: i, Offset: j + 1})
 					continue
 				}
 
 				res[fl] = []string{}
+				pos[fl] = &flagPosition{flag: i}
 				currentFlags = append(currentFlags, fl)
 			}
 		} else {
-			if len(currentFlags) <= 0 {
-				errs = append(errs, ARGUMENT_NOT_NEED(el))
-				continue
-			}
-
-			if len(currentFlags) == 1 {
-				res[currentFlags[0]] = append(res[currentFlags[0]], el)
-				continue
-			}
-
-			if len(currentFlags) > 1 {
-				res[currentFlags[0]] = append(res[currentFlags[0]], el)
-				currentFlags = currentFlags[1:]
-			}
-
+			value(i, el)
 		}
 	}
 
@@ -201,5 +299,24 @@ func ParseWithShortcuts(args []string, shortcuts map[rune]string) (map[string][]
 		err = mega("got some errors", errs)
 	}
 
-	return res, err
+	return res, pos, err
+}
+
+// it reports whether the argument is a negative number (e.g. "-5", "-1.5" or
+// "-1m30s"), so it is a value and not shortcuts. Arguments, that start with a
+// shortcut, are always shortcuts.
+func isNegativeNumber(arg string, shortcuts map[rune]string) bool {
+	s, size := utf8.DecodeRuneInString(strings.TrimPrefix(arg, "-"))
+	if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") || size == 0 {
+		return false
+	}
+	if _, ok := shortcuts[s]; ok {
+		return false
+	}
+
+	if _, err := strconv.ParseComplex(arg, 128); err == nil {
+		return true
+	}
+	_, err := time.ParseDuration(arg)
+	return err == nil
 }
@@ -0,0 +1,81 @@
+package flags
+
+import (
+	"os"
+	"reflect"
+)
+
+// it parses command-line arguments and loads the results into a new struct
+// of type T.
+//
+// It works the same way as [Load], but the struct is allocated and returned,
+// so the type is checked by the compiler. If T is a pointer to a struct, the
+// struct is allocated too.
+//
+// Example:
+//
+//	opts, err := flags.LoadAs[Options](os.Args[1:])
+//
+// The struct is returned with the values inserted so far even with an error.
+func LoadAs[T any](args []string) (T, error) {
+	return LoadAsWith[T](new(Parser), args)
+}
+
+// it parses command-line arguments (from [os.Args]) and loads the results
+// into a new struct of type T (see [LoadAs]).
+func ArgsAs[T any]() (T, error) {
+	return LoadAs[T](os.Args[1:])
+}
+
+// it parses command-line arguments and loads the results into a new struct
+// of type T using the parser settings (see [LoadAs] and [Parser.Load]).
+func LoadAsWith[T any](p *Parser, args []string) (T, error) {
+	var res T
+	v := reflect.ValueOf(&res).Elem()
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		return res, p.Load(args, res)
+	}
+	return res, p.Load(args, &res)
+}
+
+// it returns the value of the flag from parsed flags (see [Parse]) converted
+// to type T.
+//
+// Values are converted with the same rules as [Insert] uses for a field of
+// type T: a flag without values is true for bool, slices take all values
+// and other types take a single value.
+//
+// Example, for "--port 8080":
+//
+//	port, err := flags.Get[int](parsed, "port")
+//
+// It returns the [NO_FLAG] error if the flag isn't in parsed flags.
+func Get[T any](flags map[string][]string, name string) (T, error) {
+	var res T
+	args, ok := flags[name]
+	if !ok {
+		return res, NO_FLAG(name)
+	}
+
+	v := reflect.ValueOf(&res).Elem()
+	var err error
+	if v.Type() == timeType {
+		err = setTime(v, args, name)
+	} else {
+		err = setValue(args, v, name)
+	}
+	if err != nil {
+		return res, withField(err, name, "", nil, args)
+	}
+	return res, nil
+}
+
+// it works the same way as [Get], but returns the default value if the flag
+// isn't in parsed flags.
+func GetOr[T any](flags map[string][]string, name string, def T) (T, error) {
+	if _, ok := flags[name]; !ok {
+		return def, nil
+	}
+	return Get[T](flags, name)
+}
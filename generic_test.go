@@ -0,0 +1,82 @@
+package flags_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vandi37/flags"
+)
+
+type generic struct {
+	Port int
+	Host string `required:"true"`
+}
+
+func TestLoadAs(t *testing.T) {
+	val, err := flags.LoadAs[generic](strings.Fields("--port 80 --host 'localhost'"))
+	if err != nil || val.Port != 80 || val.Host != "localhost" {
+		t.Fatalf("got %+v and error %v", val, err)
+	}
+
+	ptr, err := flags.LoadAs[*generic](strings.Fields("--port 80 --host 'localhost'"))
+	if err != nil || ptr == nil || ptr.Port != 80 {
+		t.Fatalf("got %+v and error %v", ptr, err)
+	}
+
+	val, err = flags.LoadAsWith[generic](&flags.Parser{Validate: true}, strings.Fields("--port 80"))
+	if !errors.Is(err, flags.REQUIRED_FLAG()) || val.Port != 80 {
+		t.Fatalf("got %+v and error %v", val, err)
+	}
+
+	if _, err := flags.LoadAs[int](nil); !errors.Is(err, flags.IS_NOT_A_STRUCT()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.IS_NOT_A_STRUCT(), err)
+	}
+
+	p := &flags.Parser{Shortcuts: map[rune]string{'p': "port"}}
+	if val, err := flags.LoadAsWith[generic](p, strings.Fields("-p 80 --host 'h'")); err != nil || val.Port != 80 {
+		t.Fatalf("got %+v and error %v", val, err)
+	}
+}
+
+func TestGet(t *testing.T) {
+	parsed, err := flags.Parse(strings.Fields("--port 80 --host 'localhost' --verbose --ids 1 2 3 --timeout 5s --start 2024-01-02T03:04:05Z --bad x"))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	if port, err := flags.Get[uint16](parsed, "port"); err != nil || port != 80 {
+		t.Fatalf("got %v and error %v", port, err)
+	}
+	if host, err := flags.Get[string](parsed, "host"); err != nil || host != "localhost" {
+		t.Fatalf("got %v and error %v", host, err)
+	}
+	if verbose, err := flags.Get[bool](parsed, "verbose"); err != nil || !verbose {
+		t.Fatalf("got %v and error %v", verbose, err)
+	}
+	if ids, err := flags.Get[[]int](parsed, "ids"); err != nil || !slices.Equal(ids, []int{1, 2, 3}) {
+		t.Fatalf("got %v and error %v", ids, err)
+	}
+	if timeout, err := flags.Get[time.Duration](parsed, "timeout"); err != nil || timeout != 5*time.Second {
+		t.Fatalf("got %v and error %v", timeout, err)
+	}
+	if start, err := flags.Get[time.Time](parsed, "start"); err != nil || start.Year() != 2024 {
+		t.Fatalf("got %v and error %v", start, err)
+	}
+	if limit, err := flags.GetOr(parsed, "limit", 10); err != nil || limit != 10 {
+		t.Fatalf("got %v and error %v", limit, err)
+	}
+
+	var conv *flags.ConversionError
+	if _, err := flags.Get[int](parsed, "bad"); !errors.As(err, &conv) || conv.Flag != "bad" || conv.TargetType != "int" {
+		t.Fatalf("got error %v", err)
+	}
+	if _, err := flags.Get[int](parsed, "ids"); !errors.Is(err, flags.TOO_MANY_ARGUMENTS()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.TOO_MANY_ARGUMENTS(), err)
+	}
+	if _, err := flags.Get[int](parsed, "limit"); !errors.Is(err, flags.NO_FLAG()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.NO_FLAG(), err)
+	}
+}
@@ -0,0 +1,77 @@
+package flags
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// it reads an INI file into a map of flag names to their values.
+//
+// Each `key = value` line is a flag with a value, a line with only a key is a
+// flag without values. A key used several times gets multiple values. Lines
+// starting with ";" or "#" are comments.
+//
+// Sections are the same as the `prefix` tag of nested structs (see [Insert]).
+//
+// Example, section "db" with key "host":
+//
+//	[db]
+//	host = 'localhost'
+//
+// is the same as `--db.host 'localhost'`
+//
+// Values are used as is, so string values need brackets as in the command line.
+//
+// It returns a map in the same form as [Parse], so it could be merged with
+// command-line flags and inserted with [Insert].
+func ReadINI(r io.Reader) (map[string][]string, error) {
+	res := make(map[string][]string)
+	errs := []error{}
+
+	section := ""
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				errs = append(errs, BAD_LINE(n, line, "section isn't closed"))
+				continue
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section != "" {
+				section += "."
+			}
+			continue
+		}
+
+		key, val, hasVal := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			errs = append(errs, BAD_LINE(n, line, "key is empty"))
+			continue
+		}
+
+		key = section + key
+		if _, ok := res[key]; !ok {
+			res[key] = []string{}
+		}
+		if hasVal {
+			res[key] = append(res[key], strings.TrimSpace(val))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return res, err
+	}
+
+	var err error
+	if len(errs) > 0 {
+		err = mega("got some errors", errs)
+	}
+
+	return res, err
+}
@@ -0,0 +1,198 @@
+package convert
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var quotationMarks = []string{`"`, "'", "`"}
+
+// it returns the string without brackets, it reports false if the string isn't in brackets.
+func String(s string) (string, bool) {
+	for _, mark := range quotationMarks {
+		if strings.HasPrefix(s, mark) && strings.HasSuffix(s, mark) {
+			return strings.TrimPrefix(strings.TrimSuffix(s, mark), mark), true
+		}
+	}
+
+	return "", false
+}
+
+// it wraps a string with brackets, so [String] returns it unchanged.
+func Quote(s string) string {
+	return quotationMarks[0] + s + quotationMarks[0]
+}
+
+// it converts a signed integer, bases from 2 to 16 and durations are allowed.
+func Int(s string, size int) (int64, bool) {
+	if n, err := strconv.ParseInt(s, 10, size); err == nil {
+		return n, true
+	}
+	for base := 2; base <= 16; base++ {
+		if n, err := strconv.ParseInt(s, base, size); err == nil {
+			return n, true
+		}
+	}
+
+	if n, err := time.ParseDuration(s); err == nil {
+		return int64(n), true
+	}
+	return 0, false
+}
+
+// it converts an unsigned integer, bases from 2 to 16 are allowed.
+func Uint(s string, size int) (uint64, bool) {
+	if n, err := strconv.ParseUint(s, 10, size); err == nil {
+		return n, true
+	}
+	for base := 2; base <= 16; base++ {
+		if n, err := strconv.ParseUint(s, base, size); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// The accepted time formats.
+var TimeFormats = []string{
+	time.Layout,
+	time.ANSIC,
+	time.UnixDate,
+	time.RubyDate,
+	time.RFC822,
+	time.RFC822Z,
+	time.RFC850,
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC3339,
+	time.RFC3339Nano,
+	time.Kitchen,
+	time.Stamp,
+	time.StampMilli,
+	time.StampMicro,
+	time.StampNano,
+	time.DateTime,
+	time.DateOnly,
+	time.TimeOnly,
+}
+
+// it converts a time with the first matching format of [TimeFormats], the
+// value may be in brackets.
+func Time(s string) (time.Time, bool) {
+	if c, ok := String(s); ok {
+		s = c
+	}
+	for _, format := range TimeFormats {
+		if t, err := time.Parse(format, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// The conversions of a single value for fields, they return [ConversionError]
+// with the type name.
+
+func BoolArg(arg string) (bool, error) {
+	b, err := strconv.ParseBool(arg)
+	if err != nil {
+		return false, Conversion(arg, "bool", err)
+	}
+	return b, nil
+}
+
+func IntArg(arg string, size int, typ string) (int64, error) {
+	if n, ok := Int(arg, size); ok {
+		return n, nil
+	}
+	_, err := strconv.ParseInt(arg, 10, size)
+	return 0, Conversion(arg, typ, err)
+}
+
+func UintArg(arg string, size int, typ string) (uint64, error) {
+	if n, ok := Uint(arg, size); ok {
+		return n, nil
+	}
+	_, err := strconv.ParseUint(arg, 10, size)
+	return 0, Conversion(arg, typ, err)
+}
+
+func FloatArg(arg string, size int) (float64, error) {
+	f, err := strconv.ParseFloat(arg, size)
+	if err != nil {
+		return 0, Conversion(arg, "float"+strconv.Itoa(size), err)
+	}
+	return f, nil
+}
+
+func ComplexArg(arg string, size int) (complex128, error) {
+	c, err := strconv.ParseComplex(arg, size)
+	if err != nil {
+		return 0, Conversion(arg, "complex"+strconv.Itoa(size), err)
+	}
+	return c, nil
+}
+
+func StringArg(arg string) (string, error) {
+	if s, ok := String(arg); ok {
+		return s, nil
+	}
+	return "", Conversion(arg, "string", nil)
+}
+
+func TimeArg(arg string) (time.Time, error) {
+	if t, ok := Time(arg); ok {
+		return t, nil
+	}
+	return time.Time{}, Conversion(arg, "time.Time", nil)
+}
+
+// it checks, that all values are allowed by the `enum` tag.
+func CheckEnum(enum []string, args []string, flag string) error {
+	if enum == nil {
+		return nil
+	}
+
+	for _, arg := range args {
+		value := arg
+		if s, ok := String(arg); ok {
+			value = s
+		}
+		if !slices.Contains(enum, value) {
+			return &NotAllowedError{Flag: flag, Value: arg, Allowed: enum, Position: -1}
+		}
+	}
+
+	return nil
+}
+
+// it returns the environment variable of a flag from the `env` tag, "-"
+// disables it. If the tag is empty and the prefix isn't, the name is built
+// with [EnvName].
+func EnvVariable(tag string, prefix string, flag string) string {
+	if tag == "-" {
+		return ""
+	}
+	if tag == "" && prefix != "" {
+		return EnvName(prefix, flag)
+	}
+	return tag
+}
+
+// it builds an environment variable name from a prefix and a flag name.
+//
+// Example, prefix "APP" and flag "max_conns":
+// `APP_MAX_CONNS`
+func EnvName(prefix string, flag string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, flag)
+
+	return strings.TrimSuffix(prefix, "_") + "_" + name
+}
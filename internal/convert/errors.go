@@ -0,0 +1,186 @@
+// Package convert holds the conversions of values and the errors of fields,
+// that are shared by the flags and bind packages.
+//
+// It doesn't use reflection (and the fmt package), so code generated by
+// flagsgen doesn't depend on it.
+package convert
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// it is an error with a name, errors with the same name are the same for [errors.Is].
+type Error struct {
+	Name    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	return IsError(e.Name, target)
+}
+
+// it reports whether the target is an error with the name (see [Error.Is]).
+func IsError(name string, target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return name == target.Error()
+	}
+
+	return t.Name == name
+}
+
+// it returns the error of a required flag without values.
+func Required(flag string) error {
+	return &Error{Name: "required flag", Message: "flag " + flag + " is required"}
+}
+
+// it returns the "field <path>: " prefix for error messages.
+func FieldPrefix(field string) string {
+	if field == "" {
+		return ""
+	}
+	return "field " + field + ": "
+}
+
+// it is the error for a value, that can't be converted to the type of a field.
+type ConversionError struct {
+	// The flag name without "--".
+	Flag string
+	// The path of the field, nested struct fields are separated by dots (e.g. "Db.Host").
+	Field string
+	// The value from the arguments.
+	Value string
+	// The type of the field (or of the element for slices and arrays).
+	TargetType string
+	// The error from converting (e.g. [strconv.NumError]), it may be nil.
+	Cause error
+	// The index of the value in the arguments, or -1 if it isn't from the arguments.
+	Position int
+}
+
+func (e *ConversionError) Error() string {
+	res := FieldPrefix(e.Field) + "cant convert value '" + e.Value + "' to type " + e.TargetType
+	var num *strconv.NumError
+	if errors.As(e.Cause, &num) {
+		res += ": " + num.Err.Error()
+	} else if e.Cause != nil {
+		res += ": " + e.Cause.Error()
+	}
+	return res
+}
+
+func (e *ConversionError) Is(target error) bool {
+	return IsError("can't convert", target) || (e.TargetType == "interface {}" && IsError("cant default convert", target))
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Cause
+}
+
+// it returns a [ConversionError] without a flag.
+func Conversion(value string, targetType string, cause error) error {
+	return &ConversionError{Value: value, TargetType: targetType, Cause: cause, Position: -1}
+}
+
+// it is the error for a flag with more values than its field takes.
+type ArityError struct {
+	// The flag name without "--".
+	Flag string
+	// The path of the field.
+	Field string
+	// The number of values.
+	Got int
+	// The maximum number of values.
+	Max int
+	// The index of the flag in the arguments, or -1 if it isn't from the arguments.
+	Position int
+}
+
+func (e *ArityError) Error() string {
+	return FieldPrefix(e.Field) + "flag " + e.Flag + " has to many arguments (" + strconv.Itoa(e.Got) + ", at most " + strconv.Itoa(e.Max) + ")"
+}
+
+func (e *ArityError) Is(target error) bool {
+	return IsError("too many arguments", target)
+}
+
+// it returns an [ArityError] without a field.
+func Arity(flag string, got int, max int) error {
+	return &ArityError{Flag: flag, Got: got, Max: max, Position: -1}
+}
+
+// it is the error for a value, that isn't in the `enum` tag of a field.
+type NotAllowedError struct {
+	// The flag name without "--".
+	Flag string
+	// The path of the field.
+	Field string
+	// The value from the arguments.
+	Value string
+	// The allowed values.
+	Allowed []string
+	// The index of the value in the arguments, or -1 if it isn't from the arguments.
+	Position int
+}
+
+func (e *NotAllowedError) Error() string {
+	value := e.Value
+	if s, ok := String(value); ok {
+		value = s
+	}
+	return FieldPrefix(e.Field) + "value '" + value + "' isn't allowed for flag " + e.Flag + ", allowed values: " + strings.Join(e.Allowed, ", ")
+}
+
+func (e *NotAllowedError) Is(target error) bool {
+	return IsError("not allowed", target)
+}
+
+// it is an error of a field, that isn't a [ConversionError], an [ArityError]
+// or a [NotAllowedError].
+type FieldError struct {
+	// The flag name without "--".
+	Flag string
+	// The path of the field.
+	Field string
+	// The index of the flag in the arguments, or -1 if it isn't from the arguments.
+	Position int
+	Err      error
+}
+
+func (e *FieldError) Error() string {
+	return FieldPrefix(e.Field) + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// it is a list of errors with a common message.
+type MultiError struct {
+	Name string
+	Errs []error
+}
+
+func (e *MultiError) Error() string {
+	res := e.Name + ":"
+	for _, err := range e.Errs {
+		res += "\n	- " + err.Error()
+	}
+
+	return res
+}
+
+func (e *MultiError) Unwrap() []error {
+	return e.Errs
+}
+
+// it returns a [MultiError].
+func Join(name string, errs []error) error {
+	return &MultiError{Name: name, Errs: errs}
+}
@@ -0,0 +1,57 @@
+// Package gentest checks, that code generated by flagsgen works the same way
+// as the reflection based insertion.
+package gentest
+
+import (
+	"strconv"
+	"time"
+)
+
+//go:generate go run ../../cmd/flagsgen -type Config
+
+type Level int8
+
+// it formats the level in help text.
+func (l Level) String() string { return "L" + strconv.Itoa(int(l)) }
+
+type Mode string
+
+type Base struct {
+	Verbose bool `flag:"verbose,v" usage:"Print more."`
+	Quiet   bool `alias:"q,silent"`
+}
+
+type Database struct {
+	Host    string `usage:"The host of the database." env:"DB_HOST"`
+	Port    uint16
+	Timeout time.Duration
+	TLS     *TLS `prefix:"tls"`
+}
+
+type TLS struct {
+	CertFile string
+	Insecure bool
+}
+
+type Config struct {
+	Base
+
+	Name     string `required:"true" usage:"The name of the service."`
+	Mode     Mode   `enum:"dev,prod"`
+	Level    Level
+	HTTPPort int     `alias:"port"`
+	Ratio    float32 `short:"r"`
+	Scale    complex128
+	Start    time.Time
+	Tags     []string
+	Ports    []uint
+	Flags    []bool
+	Limit    *int
+	Debug    *bool
+
+	Db      Database  `prefix:"db"`
+	Replica *Database `prefix:"replica" naming:"kebab"`
+
+	Ignored string `flag:"-"`
+	private int
+}
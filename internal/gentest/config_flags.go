@@ -0,0 +1,130 @@
+// Code generated by flagsgen -type Config; DO NOT EDIT.
+
+package gentest
+
+import (
+	"time"
+
+	"github.com/vandi37/flags/bind"
+)
+
+// it inserts flags into the struct without reflection, it works the same
+// way as flags.Insert (see BindFlags).
+func (v *Config) InsertFlags(f map[string][]string) error {
+	return v.BindFlags(bind.New(f))
+}
+
+// it inserts flags of the binder into the struct without reflection, it
+// works the same way as flags.Parser.Insert with the settings of the binder.
+func (v *Config) BindFlags(b *bind.Binder) error {
+	bind.BoolValue(b, bind.Field{Flag: "verbose", Path: "Base.Verbose", Type: "bool"}, &v.Base.Verbose)
+	bind.BoolValue(b, bind.Field{Flag: "quiet", Path: "Base.Quiet", Type: "bool"}, &v.Base.Quiet)
+	bind.Value(b, bind.Field{Flag: "name", Path: "Name", Type: "string", Quote: true, Required: true}, &v.Name, bind.ConvertString[string])
+	bind.Value(b, bind.Field{Flag: "mode", Path: "Mode", Type: "string", Quote: true, Enum: []string{"dev", "prod"}}, &v.Mode, bind.ConvertString[Mode])
+	bind.Value(b, bind.Field{Flag: "level", Path: "Level", Type: "gentest.Level"}, &v.Level, bind.ConvertInt[Level])
+	bind.Value(b, bind.Field{Flag: "h_t_t_p_port", Path: "HTTPPort", Type: "int"}, &v.HTTPPort, bind.ConvertInt[int])
+	bind.Value(b, bind.Field{Flag: "ratio", Path: "Ratio", Type: "float32"}, &v.Ratio, bind.ConvertFloat[float32])
+	bind.Value(b, bind.Field{Flag: "scale", Path: "Scale", Type: "complex128"}, &v.Scale, bind.ConvertComplex[complex128])
+	bind.Value(b, bind.Field{Flag: "start", Path: "Start", Type: "time.Time"}, &v.Start, bind.ConvertTime)
+	bind.Slice(b, bind.Field{Flag: "tags", Path: "Tags", Type: "string", Quote: true}, &v.Tags, bind.ConvertString[string])
+	bind.Slice(b, bind.Field{Flag: "ports", Path: "Ports", Type: "uint"}, &v.Ports, bind.ConvertUint[uint])
+	bind.Slice(b, bind.Field{Flag: "flags", Path: "Flags", Type: "bool"}, &v.Flags, bind.ConvertBool[bool])
+	bind.Pointer(b, bind.Field{Flag: "limit", Path: "Limit", Type: "int"}, &v.Limit, func(b *bind.Binder, f bind.Field, dst *int) {
+		bind.Value(b, f, dst, bind.ConvertInt[int])
+	})
+	bind.Pointer(b, bind.Field{Flag: "debug", Path: "Debug", Type: "bool"}, &v.Debug, func(b *bind.Binder, f bind.Field, dst *bool) {
+		bind.BoolValue(b, f, dst)
+	})
+	bind.Value(b, bind.Field{Flag: "db.host", Path: "Db.Host", Type: "string", Env: "DB_HOST", Quote: true}, &v.Db.Host, bind.ConvertString[string])
+	bind.Value(b, bind.Field{Flag: "db.port", Path: "Db.Port", Type: "uint16"}, &v.Db.Port, bind.ConvertUint[uint16])
+	bind.Value(b, bind.Field{Flag: "db.timeout", Path: "Db.Timeout", Type: "time.Duration"}, &v.Db.Timeout, bind.ConvertInt[time.Duration])
+	if v.Db.TLS == nil {
+		v.Db.TLS = new(TLS)
+	}
+	bind.Value(b, bind.Field{Flag: "db.tls.cert_file", Path: "Db.TLS.CertFile", Type: "string", Quote: true}, &v.Db.TLS.CertFile, bind.ConvertString[string])
+	bind.BoolValue(b, bind.Field{Flag: "db.tls.insecure", Path: "Db.TLS.Insecure", Type: "bool"}, &v.Db.TLS.Insecure)
+	if v.Replica == nil {
+		v.Replica = new(Database)
+	}
+	bind.Value(b, bind.Field{Flag: "replica.host", Path: "Replica.Host", Type: "string", Env: "DB_HOST", Quote: true}, &v.Replica.Host, bind.ConvertString[string])
+	bind.Value(b, bind.Field{Flag: "replica.port", Path: "Replica.Port", Type: "uint16"}, &v.Replica.Port, bind.ConvertUint[uint16])
+	bind.Value(b, bind.Field{Flag: "replica.timeout", Path: "Replica.Timeout", Type: "time.Duration"}, &v.Replica.Timeout, bind.ConvertInt[time.Duration])
+	if v.Replica.TLS == nil {
+		v.Replica.TLS = new(TLS)
+	}
+	bind.Value(b, bind.Field{Flag: "replica.tls.cert-file", Path: "Replica.TLS.CertFile", Type: "string", Quote: true}, &v.Replica.TLS.CertFile, bind.ConvertString[string])
+	bind.BoolValue(b, bind.Field{Flag: "replica.tls.insecure", Path: "Replica.TLS.Insecure", Type: "bool"}, &v.Replica.TLS.Insecure)
+	return b.Err()
+}
+
+// it returns help text for the flags of the struct without reflection, it
+// works the same way as flags.Usage (see FlagUsageWith).
+func (v *Config) FlagUsage() string {
+	return v.FlagUsageWith(bind.New(nil))
+}
+
+// it returns help text for the flags of the struct without reflection, it
+// works the same way as flags.Parser.Usage with the settings of the binder.
+func (v *Config) FlagUsageWith(b *bind.Binder) string {
+	return b.FlagUsage([]bind.FlagInfo{
+		{Name: "verbose", Group: "Base", Short: 'v', Default: bind.Default(v.Base.Verbose, bind.FormatBool[bool]), Usage: "Print more."},
+		{Name: "quiet", Group: "Base", Short: 'q', Aliases: []string{"silent"}, Default: bind.Default(v.Base.Quiet, bind.FormatBool[bool])},
+		{Name: "name", Type: "string", Default: bind.Default(v.Name, bind.FormatString[string]), Usage: "The name of the service.", Required: true},
+		{Name: "mode", Type: "string", Default: bind.Default(v.Mode, bind.FormatString[Mode]), Enum: []string{"dev", "prod"}},
+		{Name: "level", Type: "int8", Default: bind.Default(v.Level, bind.FormatStringer[Level])},
+		{Name: "h_t_t_p_port", Aliases: []string{"port"}, Type: "int", Default: bind.Default(v.HTTPPort, bind.FormatInt[int])},
+		{Name: "ratio", Short: 'r', Type: "float32", Default: bind.Default(v.Ratio, bind.FormatFloat[float32])},
+		{Name: "scale", Type: "complex128", Default: bind.Default(v.Scale, bind.FormatComplex[complex128])},
+		{Name: "start", Type: "time", Default: bind.Default(v.Start, bind.FormatTime)},
+		{Name: "tags", Type: "string...", Default: bind.DefaultSlice(v.Tags, bind.FormatString[string])},
+		{Name: "ports", Type: "uint...", Default: bind.DefaultSlice(v.Ports, bind.FormatUint[uint])},
+		{Name: "flags", Type: "bool...", Default: bind.DefaultSlice(v.Flags, bind.FormatBool[bool])},
+		{Name: "limit", Type: "int", Default: bind.DefaultPointer(v.Limit, bind.FormatInt[int])},
+		{Name: "debug", Default: bind.DefaultPointer(v.Debug, bind.FormatBool[bool])},
+		{Name: "db.host", Group: "Db", Type: "string", Default: bind.Default(v.Db.Host, bind.FormatString[string]), Usage: "The host of the database.", Env: "DB_HOST"},
+		{Name: "db.port", Group: "Db", Type: "uint16", Default: bind.Default(v.Db.Port, bind.FormatUint[uint16])},
+		{Name: "db.timeout", Group: "Db", Type: "duration", Default: bind.Default(v.Db.Timeout, bind.FormatDuration)},
+		{Name: "db.tls.cert_file", Group: "Db.TLS", Type: "string", Default: func() string {
+			if v.Db.TLS == nil {
+				return ""
+			}
+			return bind.Default(v.Db.TLS.CertFile, bind.FormatString[string])
+		}()},
+		{Name: "db.tls.insecure", Group: "Db.TLS", Default: func() string {
+			if v.Db.TLS == nil {
+				return ""
+			}
+			return bind.Default(v.Db.TLS.Insecure, bind.FormatBool[bool])
+		}()},
+		{Name: "replica.host", Group: "Replica", Type: "string", Default: func() string {
+			if v.Replica == nil {
+				return ""
+			}
+			return bind.Default(v.Replica.Host, bind.FormatString[string])
+		}(), Usage: "The host of the database.", Env: "DB_HOST"},
+		{Name: "replica.port", Group: "Replica", Type: "uint16", Default: func() string {
+			if v.Replica == nil {
+				return ""
+			}
+			return bind.Default(v.Replica.Port, bind.FormatUint[uint16])
+		}()},
+		{Name: "replica.timeout", Group: "Replica", Type: "duration", Default: func() string {
+			if v.Replica == nil {
+				return ""
+			}
+			return bind.Default(v.Replica.Timeout, bind.FormatDuration)
+		}()},
+		{Name: "replica.tls.cert-file", Group: "Replica.TLS", Type: "string", Default: func() string {
+			if v.Replica == nil || v.Replica.TLS == nil {
+				return ""
+			}
+			return bind.Default(v.Replica.TLS.CertFile, bind.FormatString[string])
+		}()},
+		{Name: "replica.tls.insecure", Group: "Replica.TLS", Default: func() string {
+			if v.Replica == nil || v.Replica.TLS == nil {
+				return ""
+			}
+			return bind.Default(v.Replica.TLS.Insecure, bind.FormatBool[bool])
+		}()},
+	})
+}
@@ -0,0 +1,166 @@
+package gentest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vandi37/flags"
+	"github.com/vandi37/flags/bind"
+)
+
+func TestInsertFlags(t *testing.T) {
+	tests := []struct {
+		args string
+		// The value of the DB_HOST environment variable.
+		env string
+	}{
+		{"--name 'api'", ""},
+		{"--name 'api' -v --silent --mode 'prod' --level 12 --port 0x1f -r 0.5 --scale 1+2i", ""},
+		{"--name 'api' --start 2024-01-02T03:04:05Z", ""},
+		{"--name 'api' --tags 'a' 'b' --ports 1 2 3 --flags true false --limit 5 --debug", ""},
+		{"--name 'api' --db.host 'db' --db.port 5432 --db.timeout 5s --db.tls.cert_file 'cert' --db.tls.insecure", ""},
+		{"--name 'api' --replica.tls.cert-file 'cert' --replica.tls.insecure false", ""},
+		{"--name 'api' --ignored 'x' --unknown", ""},
+		{"", ""},
+		{"--name api --mode 'test' --level 300 --port 1 2 --db.port 70000", ""},
+		{"--name 'api' --ratio x --scale y --start z --tags 1 --ports 'a' --flags maybe --limit 1.5 --debug 2", ""},
+		{"--name 'api'", "env-host"},
+		{"--name 'api' --db.host 'db'", "env-host"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.args+" DB_HOST="+test.env, func(t *testing.T) {
+			t.Setenv("DB_HOST", test.env)
+			p := new(flags.Parser)
+			p.Aliases, _ = flags.TagAliases(new(Config))
+			p.Shortcuts, _ = flags.TagShortcuts(new(Config))
+			parsed, err := p.Parse(strings.Fields(test.args))
+			if err != nil {
+				t.Fatalf("got an error: %v", err)
+			}
+
+			need := new(Config)
+			needErr := flags.Insert(parsed, need)
+			got := new(Config)
+			gotErr := got.InsertFlags(parsed)
+			compare(t, got, gotErr, need, needErr)
+
+			need = new(Config)
+			needErr = (&flags.Parser{Validate: true}).Insert(parsed, need)
+			got = new(Config)
+			b := bind.New(parsed)
+			b.Validate = true
+			gotErr = got.BindFlags(b)
+			compare(t, got, gotErr, need, needErr)
+		})
+	}
+}
+
+func compare(t *testing.T, got *Config, gotErr error, need *Config, needErr error) {
+	t.Helper()
+	if fmt.Sprint(gotErr) != fmt.Sprint(needErr) {
+		t.Fatalf("got error:\n%v\nexpected:\n%v", gotErr, needErr)
+	}
+	if !reflect.DeepEqual(got, need) {
+		t.Fatalf("got %+v, expected %+v", *got, *need)
+	}
+}
+
+func TestBindFlagsUnquotedStrings(t *testing.T) {
+	for _, args := range []string{
+		"--name api --mode prod --tags a 'b' --db.host db --replica.tls.cert-file cert",
+		"--name 'api' --mode test --level 3",
+	} {
+		t.Run(args, func(t *testing.T) {
+			parsed, err := flags.Parse(strings.Fields(args))
+			if err != nil {
+				t.Fatalf("got an error: %v", err)
+			}
+
+			need := new(Config)
+			needErr := (&flags.Parser{UnquotedStrings: true, Validate: true}).Insert(parsed, need)
+			got := new(Config)
+			b := bind.New(parsed)
+			b.UnquotedStrings = true
+			b.Validate = true
+			gotErr := got.BindFlags(b)
+			compare(t, got, gotErr, need, needErr)
+			if need.Name != "api" {
+				t.Fatalf("got name %q, expected %q", need.Name, "api")
+			}
+		})
+	}
+}
+
+func TestBindFlagsEnvPrefix(t *testing.T) {
+	tests := []struct {
+		args string
+		env  map[string]string
+	}{
+		{"", map[string]string{"APP_NAME": "api", "APP_TAGS": "a,b", "APP_LEVEL": "3"}},
+		{"--name 'cli'", map[string]string{"APP_NAME": "api", "APP_DB_PORT": "5432", "DB_HOST": "db"}},
+		{"", map[string]string{"APP_NAME": "api", "APP_REPLICA_TLS_CERT_FILE": "cert", "APP_RATIO": "x"}},
+		{"", map[string]string{"APP_MODE": "test"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.args+fmt.Sprint(test.env), func(t *testing.T) {
+			for key, val := range test.env {
+				t.Setenv(key, val)
+			}
+			parsed, err := flags.Parse(strings.Fields(test.args))
+			if err != nil {
+				t.Fatalf("got an error: %v", err)
+			}
+
+			need := new(Config)
+			needErr := (&flags.Parser{EnvPrefix: "APP", EnvSeparator: ",", Validate: true}).Insert(parsed, need)
+			got := new(Config)
+			b := bind.New(parsed)
+			b.EnvPrefix = "APP"
+			b.EnvSeparator = ","
+			b.Validate = true
+			gotErr := got.BindFlags(b)
+			compare(t, got, gotErr, need, needErr)
+		})
+	}
+}
+
+func TestFlagUsage(t *testing.T) {
+	limit := 0
+	tests := []*Config{
+		new(Config),
+		{
+			Base:     Base{Verbose: true},
+			Name:     "api",
+			Mode:     "prod",
+			Level:    3,
+			HTTPPort: 8080,
+			Ratio:    0.25,
+			Scale:    1 + 2i,
+			Start:    time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+			Tags:     []string{"a", ""},
+			Ports:    []uint{0, 1},
+			Flags:    []bool{false, true},
+			Limit:    &limit,
+			Db:       Database{Host: "db", Timeout: time.Second, TLS: &TLS{CertFile: "cert"}},
+			Replica:  &Database{Port: 5432},
+		},
+	}
+
+	for _, test := range tests {
+		if got, need := test.FlagUsage(), flags.Usage(test); got != need {
+			t.Fatalf("got usage:\n%s\nexpected:\n%s", got, need)
+		}
+
+		b := bind.New(nil)
+		b.EnvPrefix = "APP"
+		b.Width = 60
+		if got, need := test.FlagUsageWith(b), (&flags.Parser{EnvPrefix: "APP", Width: 60}).Usage(test); got != need {
+			t.Fatalf("got usage:\n%s\nexpected:\n%s", got, need)
+		}
+	}
+}
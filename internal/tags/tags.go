@@ -0,0 +1,109 @@
+// Package tags reads shortcuts and aliases from struct tags and checks them
+// for conflicts, it is shared by the flags package and the flagsgen command.
+package tags
+
+import (
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// it holds the flag of a field with its shortcuts and aliases from tags.
+type Field struct {
+	Flag      string
+	Shortcuts []rune
+	Aliases   []string
+}
+
+// it returns the shortcuts of a field from the `short` tag, from the `flag`
+// tag after a comma and from the `alias` tag.
+//
+// If the shortcut from the `short` or `flag` tag isn't a single character,
+// it is returned as bad.
+func Shortcuts(tag reflect.StructTag) (res []rune, bad string) {
+	res = []rune{}
+	short := tag.Get("short")
+	if short == "" {
+		_, short, _ = strings.Cut(tag.Get("flag"), ",")
+	}
+	if short != "" {
+		if utf8.RuneCountInString(short) != 1 || short == "-" {
+			return nil, short
+		}
+		s, _ := utf8.DecodeRuneInString(short)
+		res = append(res, s)
+	}
+
+	for _, alias := range List(tag.Get("alias")) {
+		if s, size := utf8.DecodeRuneInString(alias); size == len(alias) && s != '-' {
+			res = append(res, s)
+		}
+	}
+	return res, ""
+}
+
+// it returns the full names of aliases from the `alias` tag with the prefix
+// of the nested struct, aliases with a single character are shortcuts (see
+// [Shortcuts]).
+func Aliases(tag reflect.StructTag, prefix string) []string {
+	res := []string{}
+	for _, alias := range List(tag.Get("alias")) {
+		if utf8.RuneCountInString(alias) > 1 {
+			res = append(res, prefix+alias)
+		}
+	}
+	return res
+}
+
+// it splits a tag with comma separated values, empty values are skipped.
+func List(tag string) []string {
+	res := []string{}
+	for _, s := range strings.Split(tag, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// it maps shortcuts of fields to flags, a shortcut of different flags is
+// reported to the conflict function and is kept for the first flag.
+func ShortcutMap(fields []Field, conflict func(s rune, first string, second string)) map[rune]string {
+	res := make(map[rune]string)
+	for _, field := range fields {
+		for _, s := range field.Shortcuts {
+			if fl, ok := res[s]; ok && fl != field.Flag {
+				conflict(s, fl, field.Flag)
+				continue
+			}
+			res[s] = field.Flag
+		}
+	}
+	return res
+}
+
+// it maps aliases of fields to flags, an alias of different flags or an
+// alias, that is the name of another flag, is reported to the conflict
+// function.
+func AliasMap(fields []Field, conflict func(alias string, first string, second string)) map[string]string {
+	names := make(map[string]bool)
+	for _, field := range fields {
+		names[field.Flag] = true
+	}
+
+	res := make(map[string]string)
+	for _, field := range fields {
+		for _, alias := range field.Aliases {
+			if fl, ok := res[alias]; ok && fl != field.Flag {
+				conflict(alias, fl, field.Flag)
+				continue
+			}
+			if names[alias] && alias != field.Flag {
+				conflict(alias, alias, field.Flag)
+				continue
+			}
+			res[alias] = field.Flag
+		}
+	}
+	return res
+}
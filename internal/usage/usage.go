@@ -0,0 +1,188 @@
+// Package usage writes help text for flags, it is shared by the flags and
+// bind packages, so generated help text is the same as [flags.Usage] gives.
+//
+// It doesn't use reflection.
+package usage
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// it describes a flag for help text.
+type Flag struct {
+	// The full flag name.
+	Name string
+	// The path of the nested struct with the flag.
+	Group string
+	// The shortcut or zero.
+	Short rune
+	// Other full names of the flag.
+	Aliases []string
+	// The type name (e.g. "int", "duration" or "string...").
+	Type string
+	// The default value as it is written in the command line.
+	Default string
+	// The `usage` tag.
+	Usage string
+	// The `env` tag.
+	Env string
+	// The values from the `enum` tag.
+	Enum []string
+	// It is true for fields with the `required:"true"` tag.
+	Required bool
+}
+
+// it writes help text for the flags, descriptions are wrapped to the width.
+func Write(w io.Writer, flags []Flag, width int) error {
+	groups := Group(flags, func(f Flag) string { return f.Group })
+	col := 0
+	for _, f := range flags {
+		col = max(col, len(f.flag())+3)
+	}
+	col = min(col, width/2)
+
+	var b strings.Builder
+	for i, group := range groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if group[0].Group == "" {
+			b.WriteString("Flags:\n")
+		} else {
+			b.WriteString(group[0].Group + ":\n")
+		}
+
+		for _, f := range group {
+			Columns(&b, f.flag(), f.description(), col, width)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// it returns the width for help text, if it is zero, the COLUMNS
+// environment variable or 80 is used.
+func Width(width int, lookupEnv func(key string) (string, bool)) int {
+	if width > 0 {
+		return width
+	}
+	if val, ok := lookupEnv("COLUMNS"); ok {
+		if n, err := strconv.Atoi(val); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 80
+}
+
+// it groups items by nested structs, top level items are first.
+func Group[T any](items []T, group func(T) string) [][]T {
+	groups := [][]T{}
+	index := map[string]int{}
+	for _, item := range items {
+		if group(item) == "" {
+			if _, ok := index[""]; !ok {
+				index[""] = len(groups)
+				groups = append(groups, nil)
+			}
+		}
+	}
+
+	for _, item := range items {
+		i, ok := index[group(item)]
+		if !ok {
+			i = len(groups)
+			index[group(item)] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], item)
+	}
+
+	return groups
+}
+
+func (f Flag) flag() string {
+	res := "  "
+	if f.Short != 0 {
+		res += "-" + string(f.Short) + ", "
+	} else {
+		res += "    "
+	}
+
+	res += "--" + f.Name
+	for _, alias := range f.Aliases {
+		res += ", --" + alias
+	}
+	if f.Type != "" {
+		res += " " + f.Type
+	}
+
+	return res
+}
+
+func (f Flag) description() string {
+	parts := []string{}
+	if f.Usage != "" {
+		parts = append(parts, f.Usage)
+	}
+	if f.Default != "" {
+		parts = append(parts, "(default "+f.Default+")")
+	}
+	if f.Env != "" {
+		parts = append(parts, "(env "+f.Env+")")
+	}
+	if f.Enum != nil {
+		parts = append(parts, "(one of: "+strings.Join(f.Enum, ", ")+")")
+	}
+	if f.Required {
+		parts = append(parts, "(required)")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// it writes the left column and the wrapped right column.
+func Columns(b *strings.Builder, left string, right string, col int, width int) {
+	b.WriteString(left)
+	if right == "" {
+		b.WriteString("\n")
+		return
+	}
+
+	pos := len(left)
+	if pos+2 > col {
+		b.WriteString("\n")
+		pos = 0
+	}
+
+	for i, line := range Wrap(right, width-col) {
+		if i > 0 {
+			pos = 0
+		}
+		b.WriteString(strings.Repeat(" ", col-pos))
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+}
+
+// it splits the text into lines not longer than the width (if words aren't longer).
+func Wrap(text string, width int) []string {
+	width = max(width, 20)
+
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+
+	return append(lines, line)
+}
@@ -0,0 +1,154 @@
+package flags
+
+import (
+	"io"
+	"strings"
+)
+
+// it is a documentation page for a program or a command.
+type docPage struct {
+	// The full name, e.g. "app serve".
+	name        string
+	description string
+	flags       []flagDoc
+	// Names and descriptions of subcommands.
+	commands [][2]string
+}
+
+func (p *Parser) docPage(name string, description string, v any) (docPage, error) {
+	docs, err := p.flagDocs(v)
+	if err != nil {
+		return docPage{}, err
+	}
+	return docPage{name: name, description: description, flags: docs}, nil
+}
+
+// it writes a man page (section 1) for a program with the flags of a struct.
+//
+// The page has the name, the synopsis, the description, all flags grouped by
+// nested structs (with the same information as [Usage]) and the environment
+// variables. The output depends only on the arguments, so it could be
+// compared with a saved file.
+func WriteMan(w io.Writer, name string, description string, v any) error {
+	return new(Parser).WriteMan(w, name, description, v)
+}
+
+// it writes a man page using the parser settings (see [WriteMan]).
+func (p *Parser) WriteMan(w io.Writer, name string, description string, v any) error {
+	page, err := p.docPage(name, description, v)
+	if err != nil {
+		return err
+	}
+	return writeMan(w, page)
+}
+
+func writeMan(w io.Writer, page docPage) error {
+	var b strings.Builder
+
+	b.WriteString(".TH " + roff(strings.ToUpper(strings.ReplaceAll(page.name, " ", "-"))) + " 1\n")
+	b.WriteString(".SH NAME\n")
+	b.WriteString(roffLine(strings.ReplaceAll(page.name, " ", "-")))
+	if page.description != "" {
+		b.WriteString(` \- ` + roff(page.description))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(".SH SYNOPSIS\n")
+	b.WriteString(".B " + roff(page.name) + "\n")
+	if len(page.commands) > 0 {
+		b.WriteString("[\\fICOMMAND\\fR]\n")
+	}
+	b.WriteString("[\\fIFLAGS\\fR]\n")
+
+	if page.description != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(roffLine(page.description) + "\n")
+	}
+
+	if len(page.commands) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+		for _, command := range page.commands {
+			b.WriteString(".TP\n")
+			b.WriteString(`\fB` + roff(command[0]) + `\fR` + "\n")
+			if command[1] != "" {
+				b.WriteString(roffLine(command[1]) + "\n")
+			}
+		}
+	}
+
+	if len(page.flags) > 0 {
+		b.WriteString(".SH FLAGS\n")
+	}
+	envs := []flagDoc{}
+	for _, group := range groupDocs(page.flags) {
+		if group[0].group != "" {
+			b.WriteString(".SS " + roff(group[0].group) + "\n")
+		}
+
+		for _, doc := range group {
+			b.WriteString(".TP\n")
+			if doc.short != 0 {
+				b.WriteString(`\fB\-` + roff(string(doc.short)) + `\fR, `)
+			}
+			b.WriteString(`\fB\-\-` + roff(doc.name) + `\fR`)
+			for _, alias := range doc.aliases {
+				b.WriteString(`, \fB\-\-` + roff(alias) + `\fR`)
+			}
+			if doc.typ != "" {
+				b.WriteString(` \fI` + roff(doc.typ) + `\fR`)
+			}
+			b.WriteString("\n")
+
+			lines := []string{}
+			if doc.usage != "" {
+				lines = append(lines, roffLine(doc.usage))
+			}
+			if doc.def != "" {
+				lines = append(lines, "Default: "+roff(doc.def)+".")
+			}
+			if doc.enum != nil {
+				lines = append(lines, "One of: "+roff(strings.Join(doc.enum, ", "))+".")
+			}
+			if doc.env != "" {
+				lines = append(lines, "Environment: "+roff(doc.env)+".")
+				envs = append(envs, doc)
+			}
+			if doc.required {
+				lines = append(lines, "Required.")
+			}
+			b.WriteString(strings.Join(lines, "\n.br\n"))
+			if len(lines) > 0 {
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	if len(envs) > 0 {
+		b.WriteString(".SH ENVIRONMENT\n")
+		for _, doc := range envs {
+			b.WriteString(".TP\n")
+			b.WriteString(".B " + roff(doc.env) + "\n")
+			b.WriteString(`Used if \fB\-\-` + roff(doc.name) + `\fR isn't set.` + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// it escapes text for roff.
+func roff(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	return strings.ReplaceAll(s, "-", `\-`)
+}
+
+// it escapes text for roff, that is written from the start of a line.
+func roffLine(s string) string {
+	lines := strings.Split(roff(s), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,98 @@
+package flags
+
+import (
+	"io"
+	"strings"
+)
+
+// it writes a Markdown page for a program with the flags of a struct.
+//
+// The page has the name, the description, the usage line and a table of all
+// flags grouped by nested structs (with the same information as [Usage]). The
+// output depends only on the arguments, so it could be compared with a saved
+// file.
+func WriteMarkdown(w io.Writer, name string, description string, v any) error {
+	return new(Parser).WriteMarkdown(w, name, description, v)
+}
+
+// it writes a Markdown page using the parser settings (see [WriteMarkdown]).
+func (p *Parser) WriteMarkdown(w io.Writer, name string, description string, v any) error {
+	page, err := p.docPage(name, description, v)
+	if err != nil {
+		return err
+	}
+	return writeMarkdown(w, page)
+}
+
+func writeMarkdown(w io.Writer, page docPage) error {
+	var b strings.Builder
+
+	b.WriteString("# " + page.name + "\n\n")
+	if page.description != "" {
+		b.WriteString(page.description + "\n\n")
+	}
+
+	b.WriteString("## Usage\n\n```\n" + page.name)
+	if len(page.commands) > 0 {
+		b.WriteString(" [command]")
+	}
+	b.WriteString(" [flags]\n```\n")
+
+	if len(page.commands) > 0 {
+		b.WriteString("\n## Commands\n\n")
+		b.WriteString("| Command | Description |\n| --- | --- |\n")
+		for _, command := range page.commands {
+			b.WriteString("| `" + command[0] + "` | " + markdownCell(command[1]) + " |\n")
+		}
+	}
+
+	for i, group := range groupDocs(page.flags) {
+		if i == 0 {
+			b.WriteString("\n## Flags\n")
+		}
+		if group[0].group != "" {
+			b.WriteString("\n### " + group[0].group + "\n")
+		}
+
+		b.WriteString("\n| Flag | Type | Default | Environment | Description |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, doc := range group {
+			flag := "`--" + doc.name + "`"
+			for _, alias := range doc.aliases {
+				flag += ", `--" + alias + "`"
+			}
+			if doc.short != 0 {
+				flag = "`-" + string(doc.short) + "`, " + flag
+			}
+
+			description := doc.usage
+			if doc.enum != nil {
+				description = strings.TrimSpace(description + " One of: `" + strings.Join(doc.enum, "`, `") + "`.")
+			}
+			if doc.required {
+				description = strings.TrimSpace(description + " **Required.**")
+			}
+
+			b.WriteString("| " + flag +
+				" | " + markdownCode(doc.typ) +
+				" | " + markdownCode(doc.def) +
+				" | " + markdownCode(doc.env) +
+				" | " + markdownCell(description) + " |\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + markdownCell(s) + "`"
+}
+
+// it escapes text for a table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
@@ -0,0 +1,163 @@
+package flags
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// it renders a struct back into command-line arguments, so [Load] with the
+// arguments gives the same struct.
+//
+// Flags have full names (see [Insert] for the naming rules), shortcuts and
+// aliases aren't used. Fields are rendered in the order of the struct, zero
+// fields are skipped unless they are tagged with `required:"true"`. Pointer
+// fields are rendered, if they aren't nil.
+//
+// Values are written the same way as they are parsed: strings are in
+// brackets, durations are like "1m30s", times use the [time.RFC3339Nano]
+// format, slices and arrays have a value for every element and a true bool
+// is a flag without values.
+//
+// The same struct is given back with two exceptions. Times keep their instant,
+// but not their location and monotonic clock reading (compare them with
+// [time.Time.Equal]). Values of `interface{}` fields get the types, that
+// [Insert] gives them: string, int64, float64, bool, [time.Time] or
+// complex128, several values are []any (e.g. an int is given back as an
+// int64 and a []string with one element as a string).
+//
+// Example, for `Port int` and `Host string` with port 80 and host "localhost":
+//
+//	--port 80 --host "localhost"
+//
+// It returns the [UNSUPPORTABLE_TYPE] error for fields, that can't be
+// rendered (e.g. a slice of structs).
+func Marshal(v any) ([]string, error) {
+	return new(Parser).Marshal(v)
+}
+
+// it renders a struct back into command-line arguments with flag names of
+// the parser (see [Marshal] and [Parser.Naming]).
+func (p *Parser) Marshal(v any) ([]string, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, TYPE_ERROR()
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, IS_NOT_A_STRUCT()
+	}
+
+	res := []string{}
+	errs := []error{}
+	for _, field := range p.flagFields(rv.Type()) {
+		val, err := rv.FieldByIndexErr(field.index)
+		if err != nil || (val.IsZero() && !field.required) {
+			continue
+		}
+
+		if val.Kind() == reflect.Bool && val.Bool() {
+			res = append(res, "--"+field.name)
+			continue
+		}
+
+		args, err := marshalValue(val)
+		if err != nil {
+			errs = append(errs, withField(err, field.name, field.path, nil, nil))
+			continue
+		}
+		if args != nil {
+			res = append(res, "--"+field.name)
+			res = append(res, args...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, mega("got some errors", errs)
+	}
+	return res, nil
+}
+
+// it returns the values of a field for the arguments, it is nil if there is
+// nothing to write (e.g. for an empty slice).
+func marshalValue(v reflect.Value) ([]string, error) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return marshalValue(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if kind := v.Elem().Kind(); kind == reflect.Pointer || kind == reflect.Slice || kind == reflect.Array {
+			return marshalValue(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+		res := []string{}
+		for i := 0; i < v.Len(); i++ {
+			arg, err := marshalArg(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, arg)
+		}
+		return res, nil
+	}
+
+	arg, err := marshalArg(v)
+	if err != nil {
+		return nil, err
+	}
+	return []string{arg}, nil
+}
+
+// it returns a single value the same way as it is parsed (see [setValue]).
+func marshalArg(v reflect.Value) (string, error) {
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		return time.Duration(v.Int()).String(), nil
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	case reflect.Complex64:
+		return strconv.FormatComplex(v.Complex(), 'g', -1, 64), nil
+	case reflect.Complex128:
+		return strconv.FormatComplex(v.Complex(), 'g', -1, 128), nil
+	case reflect.String:
+		return quoteString(v.String()), nil
+	case reflect.UnsafePointer:
+		return strconv.FormatUint(uint64(v.Pointer()), 10), nil
+	case reflect.Interface:
+		if v.IsNil() {
+			break
+		}
+		arg, err := marshalArg(v.Elem())
+		// Floats without a fraction get one, so they aren't inserted as int64.
+		if kind := v.Elem().Kind(); err == nil && (kind == reflect.Float32 || kind == reflect.Float64) {
+			if _, ok := convertInt(arg, 64); ok {
+				arg += ".0"
+			}
+		}
+		return arg, err
+	}
+	return "", UNSUPPORTABLE_TYPE(v.Type().String())
+}
@@ -0,0 +1,140 @@
+package flags_test
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vandi37/flags"
+)
+
+type marshalled struct {
+	Name     string `flag:"name,n" required:"true"`
+	Quote    string
+	Offset   int
+	Port     uint16
+	Ratio    float64
+	Scale    complex64
+	Verbose  bool `short:"v"`
+	Timeout  time.Duration
+	Start    time.Time
+	Tags     []string
+	Deltas   []int
+	Pair     [2]bool
+	Limit    *int
+	Debug    *bool
+	Value    any
+	HTTPPort int `alias:"port-alias"`
+	Db       struct {
+		Host string
+	} `prefix:"db"`
+	Ignored string `flag:"-"`
+}
+
+func TestMarshal(t *testing.T) {
+	limit, debug := 0, false
+	val := marshalled{
+		Name:     "api",
+		Quote:    `it's "quoted" -x`,
+		Offset:   -5,
+		Port:     8080,
+		Ratio:    -0.25,
+		Scale:    1 - 2i,
+		Verbose:  true,
+		Timeout:  -90 * time.Second,
+		Start:    time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		Tags:     []string{"a", "b c"},
+		Deltas:   []int{-1, 0, 1},
+		Pair:     [2]bool{false, true},
+		Limit:    &limit,
+		Debug:    &debug,
+		Value:    "x",
+		HTTPPort: 1,
+	}
+	val.Db.Host = "localhost"
+
+	args, err := flags.Marshal(&val)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if !slices.Contains(args, "--verbose") || !slices.Contains(args, "--h_t_t_p_port") || slices.Contains(args, "--ignored") {
+		t.Fatalf("got arguments %q", args)
+	}
+
+	got := marshalled{}
+	if err := flags.Load(args, &got); err != nil {
+		t.Fatalf("got an error: %v for arguments %q", err, args)
+	}
+	if !reflect.DeepEqual(got, val) {
+		t.Fatalf("got %+v, expected %+v", got, val)
+	}
+
+	args, err = flags.Marshal(marshalled{})
+	if need := []string{"--name", `""`}; err != nil || !slices.Equal(args, need) {
+		t.Fatalf("got arguments %q and error %v, expected %q", args, err, need)
+	}
+
+	args, err = (&flags.Parser{Naming: flags.KebabCase}).Marshal(struct{ HTTPPort int }{80})
+	if need := []string{"--http-port", "80"}; err != nil || !slices.Equal(args, need) {
+		t.Fatalf("got arguments %q and error %v, expected %q", args, err, need)
+	}
+}
+
+func TestMarshalInterface(t *testing.T) {
+	type withAny struct {
+		Value any
+		Start time.Time
+	}
+
+	for _, val := range []any{"x", int64(-3), 2.0, 0.5, true, 1 + 2i, time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), []any{int64(1), "a", 1.0}} {
+		start := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("X", 3600))
+		args, err := flags.Marshal(withAny{Value: val, Start: start})
+		if err != nil {
+			t.Fatalf("got an error: %v", err)
+		}
+
+		got := withAny{}
+		if err := flags.Load(args, &got); err != nil {
+			t.Fatalf("got an error: %v for arguments %q", err, args)
+		}
+		if !reflect.DeepEqual(got.Value, val) || !got.Start.Equal(start) {
+			t.Fatalf("got %#v and %v for arguments %q, expected %#v and %v", got.Value, got.Start, args, val, start)
+		}
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	if _, err := flags.Marshal(1); !errors.Is(err, flags.IS_NOT_A_STRUCT()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.IS_NOT_A_STRUCT(), err)
+	}
+	if _, err := flags.Marshal((*marshalled)(nil)); !errors.Is(err, flags.TYPE_ERROR()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.TYPE_ERROR(), err)
+	}
+
+	type unsupported struct {
+		Values map[string]int
+	}
+	_, err := flags.Marshal(unsupported{Values: map[string]int{"a": 1}})
+	var field *flags.FieldError
+	if !errors.Is(err, flags.UNSUPPORTABLE_TYPE()) || !errors.As(err, &field) || field.Field != "Values" {
+		t.Fatalf("got error %v", err)
+	}
+}
+
+func TestNegativeValues(t *testing.T) {
+	parsed, err := flags.Parse(strings.Fields("--offset -5 --ratio -1.5 --timeout -1m30s"))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if !slices.Equal(parsed["offset"], []string{"-5"}) || !slices.Equal(parsed["timeout"], []string{"-1m30s"}) {
+		t.Fatalf("got %v", parsed)
+	}
+
+	parsed, err = flags.ParseWithShortcuts(strings.Fields("--offset -5"), map[rune]string{'5': "five"})
+	if err != nil || parsed["five"] == nil {
+		t.Fatalf("expected shortcut '5', got %v and error %v", parsed, err)
+	}
+}
@@ -0,0 +1,198 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// it is a named set of flags, like the result of [Parse], [ReadJSON],
+// [ReadINI] or [ReadDotenv].
+type Source struct {
+	Name  string
+	Flags map[string][]string
+	// If it is true, values of the source override environment variables of
+	// fields, like command-line arguments do. Otherwise environment variables
+	// override them (see [Parser.InsertSources]).
+	OverrideEnv bool
+	// If it is true, the keys are environment variables (like the result of
+	// [ReadDotenv]), [Parser.InsertSources] binds them to fields the same way
+	// as environment variables. [Merge] uses the keys as they are.
+	Env bool
+}
+
+// it maps flag names to names of the sources, that set them.
+type Origins map[string]string
+
+// The name of the source with command-line arguments (see [Parser.LoadReport]).
+const argumentsSource = "arguments"
+
+// it is the source, that set a flag.
+type origin struct {
+	name        string
+	overrideEnv bool
+}
+
+// it combines flags from multiple sources into one map.
+//
+// Sources are used in the given order, so a later source overrides values of
+// the same flag from an earlier source.
+//
+// Example, sources for defaults, a config file, environment and arguments:
+// `Merge(defaults, file, env, args)`
+//
+// It also returns the name of the source, that set each flag.
+func Merge(sources ...Source) (map[string][]string, Origins) {
+	res, merged := merge(sources)
+	origins := make(Origins)
+	for flag, o := range merged {
+		origins[flag] = o.name
+	}
+
+	return res, origins
+}
+
+func merge(sources []Source) (map[string][]string, map[string]origin) {
+	res := make(map[string][]string)
+	origins := make(map[string]origin)
+
+	for _, source := range sources {
+		for flag, args := range source.Flags {
+			res[flag] = args
+			origins[flag] = origin{name: source.Name, overrideEnv: source.OverrideEnv}
+		}
+	}
+
+	return res, origins
+}
+
+// it merges the sources (see [Merge]) and inserts the results into a struct
+// using the parser settings.
+//
+// Environment variables of fields (see [Insert]) override values from
+// sources, but not from sources with [Source.OverrideEnv], so the order is:
+// sources without OverrideEnv, then environment variables, then sources with
+// OverrideEnv, both in the given order.
+//
+// Example, defaults, a config file, environment and arguments:
+// `p.InsertSources(v, defaults, file, Source{Name: "cli", Flags: args, OverrideEnv: true})`
+//
+// It returns a report with the source of every field.
+func (p *Parser) InsertSources(v any, sources ...Source) (*Report, error) {
+	return p.insertSources(nil, v, sources...)
+}
+
+func (p *Parser) insertSources(pos positions, v any, sources ...Source) (*Report, error) {
+	sources = slices.Clone(sources)
+	slices.SortStableFunc(sources, func(a, b Source) int {
+		if a.OverrideEnv == b.OverrideEnv {
+			return 0
+		}
+		if b.OverrideEnv {
+			return -1
+		}
+		return 1
+	})
+
+	for i, source := range sources {
+		if t := reflect.TypeOf(v); source.Env && t != nil {
+			sources[i].Flags = p.envFlags(source.Flags, t)
+		}
+	}
+
+	flags, origins := merge(sources)
+	return p.insertReport(flags, origins, pos, v)
+}
+
+// it is the source of the value of one field.
+type FieldOrigin struct {
+	// The path of the field, nested struct fields are separated by dots (e.g. "Db.Host").
+	Field string
+	// The flag name of the field.
+	Flag string
+	// The name of the source, that set the field. Fields from environment
+	// variables have the source "env <VARIABLE>". It is empty, if the field
+	// isn't set.
+	Source string
+	// The values, that were used to set the field.
+	Values []string
+}
+
+// it records where values of the fields of a struct came from.
+type Report struct {
+	Fields []FieldOrigin
+	// Flags, that aren't bound to any field, with their values as arguments
+	// in the order of the command line (e.g. "--child_flag", "a", "b"), so
+	// they could be passed to other programs (see [Parser.Strict]).
+	Unknown []string
+}
+
+// it returns the name of the source, that set the field.
+//
+// The field could be given by its path (e.g. "Db.Host") or its flag name
+// (e.g. "db.host"). It returns false if the field isn't set.
+func (r *Report) Origin(field string) (string, bool) {
+	for _, f := range r.Fields {
+		if (f.Field == field || f.Flag == field) && f.Source != "" {
+			return f.Source, true
+		}
+	}
+	return "", false
+}
+
+// it reports whether the field is set by any source (the arguments, a config
+// file or an environment variable), so it isn't left with its default value.
+//
+// The field could be given by its path (e.g. "Db.Host") or its flag name
+// (e.g. "db.host").
+//
+// Example, a base config with overrides from the command line:
+//
+//	report, err := flags.LoadReport(os.Args[1:], cli)
+//	if report.IsSet("port") {
+//		base.Port = cli.Port
+//	}
+func (r *Report) IsSet(field string) bool {
+	_, ok := r.Origin(field)
+	return ok
+}
+
+// it returns the flag names of the fields, that are set by any source, in
+// the order of the fields.
+func (r *Report) SetFlags() []string {
+	res := []string{}
+	for _, f := range r.Fields {
+		if f.Source != "" {
+			res = append(res, f.Flag)
+		}
+	}
+	return res
+}
+
+// it writes a table with every field, its values and its source.
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tFLAG\tVALUE\tSOURCE")
+	for _, f := range r.Fields {
+		source := f.Source
+		if source == "" {
+			source = "default"
+		}
+		fmt.Fprintf(tw, "%s\t--%s\t%s\t%s\n", f.Field, f.Flag, strings.Join(f.Values, " "), source)
+	}
+	tw.Flush()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// it returns the table written by [Report.WriteTo].
+func (r *Report) String() string {
+	var b strings.Builder
+	r.WriteTo(&b)
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package flags_test
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+func TestMerge(t *testing.T) {
+	res, origins := flags.Merge(
+		flags.Source{Name: "defaults", Flags: map[string][]string{"port": {"80"}, "host": {"'localhost'"}}},
+		flags.Source{Name: "file", Flags: map[string][]string{"port": {"8080"}}},
+		flags.Source{Name: "arguments", Flags: map[string][]string{"port": {"3700"}}},
+	)
+
+	need := map[string][]string{"port": {"3700"}, "host": {"'localhost'"}}
+	if !maps.EqualFunc(need, res, func(v1, v2 []string) bool { return slices.Equal(v1, v2) }) {
+		t.Fatalf("got different maps: expected %v, got %v", need, res)
+	}
+	if !maps.Equal(flags.Origins{"port": "arguments", "host": "defaults"}, origins) {
+		t.Fatalf("got different origins %v", origins)
+	}
+}
+
+func TestSourcesPrecedence(t *testing.T) {
+	p := &flags.Parser{LookupEnv: lookup(map[string]string{"APP_PORT": "8080", "APP_HOST": "env.com", "APP_TAGS": "x"})}
+	cli := flags.Source{Name: "cli", Flags: map[string][]string{"host": {"'example.com'"}, "tags": {"'a'"}}, OverrideEnv: true}
+	defaults := flags.Source{Name: "defaults", Flags: map[string][]string{"port": {"80"}, "host": {"'localhost'"}, "debug": {}}}
+	file := flags.Source{Name: "arguments", Flags: map[string][]string{"tags": {"'b'"}}}
+
+	// Sources with OverrideEnv are used last, whatever their place is.
+	val := new(withEnv)
+	report, err := p.InsertSources(val, cli, defaults, file)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if val.Port != 8080 || val.Host != "example.com" || !slices.Equal(val.Tags, []string{"a"}) || !val.Debug {
+		t.Fatalf("got structure %+v", *val)
+	}
+	for field, need := range map[string]string{"port": "env APP_PORT", "host": "cli", "tags": "cli", "debug": "defaults"} {
+		if source, ok := report.Origin(field); !ok || source != need {
+			t.Fatalf("got source %q for %s, expected %q", source, field, need)
+		}
+	}
+
+	// The name of a source doesn't change the precedence.
+	val = new(withEnv)
+	report, err = p.InsertSources(val, file)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if source, _ := report.Origin("tags"); !slices.Equal(val.Tags, []string{"x"}) || source != "env APP_TAGS" {
+		t.Fatalf("got structure %+v from %q", *val, source)
+	}
+	if source, ok := report.Origin("debug"); ok {
+		t.Fatalf("got source %q for an unset field", source)
+	}
+}
+
+func TestReport(t *testing.T) {
+	p := &flags.Parser{LookupEnv: lookup(map[string]string{"APP_HOST": "example.com"})}
+
+	val := new(withEnv)
+	report, err := p.LoadReport(strings.Fields("--port 3700"), val)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	for field, need := range map[string]string{"Port": "arguments", "host": "env APP_HOST"} {
+		if source, ok := report.Origin(field); !ok || source != need {
+			t.Fatalf("got source %q for %s, expected %q", source, field, need)
+		}
+	}
+	if source, ok := report.Origin("Tags"); ok {
+		t.Fatalf("got source %q for an unset field", source)
+	}
+
+	need := strings.Join([]string{
+		"FIELD  FLAG     VALUE          SOURCE",
+		"Port   --port   3700           arguments",
+		`Host   --host   "example.com"  env APP_HOST`,
+		"Tags   --tags                  default",
+		"Debug  --debug                 default",
+		"",
+	}, "\n")
+	if report.String() != need {
+		t.Fatalf("got report:\n%s\nexpected:\n%s", report, need)
+	}
+}
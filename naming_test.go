@@ -0,0 +1,78 @@
+package flags_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+func TestNaming(t *testing.T) {
+	tests := []struct {
+		naming flags.NameFunc
+		need   []string
+	}{
+		{flags.LegacySnakeCase, []string{"h_t_t_p_port", "user_i_d", "max_conns", "i_p_v6_addr"}},
+		{flags.SnakeCase, []string{"http_port", "user_id", "max_conns", "ipv6_addr"}},
+		{flags.KebabCase, []string{"http-port", "user-id", "max-conns", "ipv6-addr"}},
+		{flags.DotCase, []string{"http.port", "user.id", "max.conns", "ipv6.addr"}},
+		{flags.LowerCamelCase, []string{"httpPort", "userId", "maxConns", "ipv6Addr"}},
+		{flags.ExactCase, []string{"HTTPPort", "UserID", "MaxConns", "IPV6Addr"}},
+	}
+
+	for _, test := range tests {
+		for i, field := range []string{"HTTPPort", "UserID", "MaxConns", "IPV6Addr"} {
+			if got := test.naming(field); got != test.need[i] {
+				t.Fatalf("got name %q for %s, expected %q", got, field, test.need[i])
+			}
+		}
+	}
+}
+
+type kebabOptions struct {
+	MaxConns int
+}
+
+func (kebabOptions) FlagName(field string) string {
+	return "opt-" + flags.KebabCase(field)
+}
+
+type withNaming struct {
+	HTTPPort int
+	Db       struct {
+		MaxConns int
+	} `prefix:"db" naming:"snake"`
+	Options kebabOptions
+}
+
+func TestParserNaming(t *testing.T) {
+	val := new(withNaming)
+	p := &flags.Parser{Naming: flags.KebabCase}
+	if err := p.Load(strings.Fields("--http-port 80 --db.max_conns 10 --opt-max-conns 5"), val); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if val.HTTPPort != 80 || val.Db.MaxConns != 10 || val.Options.MaxConns != 5 {
+		t.Fatalf("got %+v", *val)
+	}
+
+	// The default naming keeps the names of older versions.
+	val = new(withNaming)
+	if err := flags.Load(strings.Fields("--h_t_t_p_port 80"), val); err != nil || val.HTTPPort != 80 {
+		t.Fatalf("got %+v and error %v", *val, err)
+	}
+	val = new(withNaming)
+	if err := (&flags.Parser{Naming: flags.SnakeCase}).Load(strings.Fields("--http_port 80"), val); err != nil || val.HTTPPort != 80 {
+		t.Fatalf("got %+v and error %v", *val, err)
+	}
+}
+
+func TestNamingCacheFuncs(t *testing.T) {
+	type cached struct{ HTTPPort int }
+	for _, naming := range []flags.NameFunc{flags.SnakeCase, flags.KebabCase, flags.SnakeCase} {
+		p := &flags.Parser{Naming: naming}
+		val := new(cached)
+		if err := p.Insert(map[string][]string{naming("HTTPPort"): {"80"}}, val); err != nil || val.HTTPPort != 80 {
+			t.Fatalf("got %+v and error %v", *val, err)
+		}
+	}
+}
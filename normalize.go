@@ -0,0 +1,110 @@
+package flags
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// it returns the flag name without "-", "_", "." and in lower case (see [Parser.Normalize]).
+func normalize(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// it renames flags to the flag names of fields, that are the same after
+// normalization (see [normalize]).
+//
+// It returns the [NAME_COLLISION] error if flag names or aliases of two fields
+// are the same after normalization and the [TWICE_FLAG] error if two flags are
+// renamed to the same field.
+func (p *Parser) normalizeFlags(flags map[string][]string, origins map[string]origin, pos positions, t reflect.Type) (map[string][]string, map[string]origin, positions, error) {
+	names := make(map[string]string)
+	errs := []error{}
+	fields := p.flagFields(t)
+	for _, field := range fields {
+		key := normalize(field.name)
+		if name, ok := names[key]; ok && name != field.name {
+			errs = append(errs, NAME_COLLISION(name, field.name))
+			continue
+		}
+		names[key] = field.name
+	}
+
+	// Aliases mustn't be the same as flag names of other fields or aliases of
+	// other flags after normalization.
+	type alias struct{ name, flag string }
+	aliases := []alias{}
+	for _, field := range fields {
+		for _, name := range fieldAliases(field) {
+			aliases = append(aliases, alias{name, field.name})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.Aliases)) {
+		aliases = append(aliases, alias{name, p.Aliases[name]})
+	}
+	aliasKeys := make(map[string]alias)
+	for _, a := range aliases {
+		key := normalize(a.name)
+		if name, ok := names[key]; ok && name != a.flag {
+			errs = append(errs, NAME_COLLISION(name, a.name))
+		} else if other, ok := aliasKeys[key]; ok && other.flag != a.flag {
+			errs = append(errs, NAME_COLLISION(other.name, a.name))
+		} else {
+			aliasKeys[key] = a
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, nil, mega("got some errors", errs)
+	}
+
+	res := make(map[string][]string)
+	var resOrigins map[string]origin
+	if origins != nil {
+		resOrigins = make(map[string]origin)
+	}
+	var resPos positions
+	if pos != nil {
+		resPos = make(positions)
+	}
+
+	// Flags with the same names as fields go first, so they aren't replaced.
+	keys := slices.SortedFunc(maps.Keys(flags), func(a string, b string) int {
+		if exactA, exactB := names[normalize(a)] == a, names[normalize(b)] == b; exactA != exactB {
+			if exactA {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	for _, key := range keys {
+		name, ok := names[normalize(key)]
+		if !ok {
+			name = key
+		}
+		if _, ok := res[name]; ok {
+			errs = append(errs, &ArgumentError{Position: pos.flag(key), Err: TWICE_FLAG(name)})
+			continue
+		}
+
+		res[name] = flags[key]
+		if source, ok := origins[key]; ok {
+			resOrigins[name] = source
+		}
+		if fp, ok := pos[key]; ok {
+			resPos[name] = fp
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, nil, mega("got some errors", errs)
+	}
+
+	return res, resOrigins, resPos, nil
+}
@@ -0,0 +1,67 @@
+package flags_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type withNormalize struct {
+	MaxConns int
+	Db       struct {
+		Host string
+	} `prefix:"db"`
+}
+
+func TestNormalize(t *testing.T) {
+	p := &flags.Parser{Normalize: true, Strict: true}
+	for _, args := range []string{"--max-conns 10 --DB-Host 'a'", "--max_conns 10 --dbhost 'a'", "--MaxConns 10 --db.host 'a'"} {
+		val := new(withNormalize)
+		if err := p.Load(strings.Fields(args), val); err != nil {
+			t.Fatalf("%q: got an error: %v", args, err)
+		}
+		if val.MaxConns != 10 || val.Db.Host != "a" {
+			t.Fatalf("%q: got %+v", args, *val)
+		}
+	}
+
+	if err := p.Load(strings.Fields("--max-conns 10 --max_conns 11"), new(withNormalize)); !errors.Is(err, flags.TWICE_FLAG()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.TWICE_FLAG(), err)
+	}
+	if err := flags.Load(strings.Fields("--max-conns 10"), new(withNormalize)); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	type collision struct {
+		MaxConns int
+		Max      struct {
+			Conns int
+		} `prefix:"max"`
+	}
+	if err := p.Load(nil, new(collision)); !errors.Is(err, flags.NAME_COLLISION()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.NAME_COLLISION(), err)
+	}
+
+	type aliasCollision struct {
+		MaxConns int
+		Limit    int `alias:"max-conns"`
+	}
+	if err := p.Load(nil, new(aliasCollision)); !errors.Is(err, flags.NAME_COLLISION()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.NAME_COLLISION(), err)
+	}
+
+	aliases := &flags.Parser{Normalize: true, Aliases: map[string]string{"MaxConns": "db.host"}}
+	if err := aliases.Load(nil, new(withNormalize)); !errors.Is(err, flags.NAME_COLLISION()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.NAME_COLLISION(), err)
+	}
+
+	// An alias may be the same as the name of its own field.
+	type ownAlias struct {
+		MaxConns int `alias:"max-conns"`
+	}
+	if err := p.Load(strings.Fields("--max-conns 3"), new(ownAlias)); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package flags
+
+import (
+	"io"
+	"os"
+)
+
+// it holds the settings used for parsing flags and inserting them into a struct.
+//
+// The zero value is ready to use and works the same way as the package level
+// functions ([Parse], [Insert], [Load], [Args]).
+type Parser struct {
+	// The short flag to full flag mappings (see [ParseWithShortcuts]).
+	Shortcuts map[rune]string
+
+	// The other names of flags, the key is the alias and the value is the
+	// full flag name. Aliases are replaced with flag names while parsing.
+	//
+	// Example, alias "debug-output" for flag "verbose":
+	// `--debug-output`
+	Aliases map[string]string
+
+	// It selects how arguments with a single dash are parsed, shortcuts are
+	// clustered by default.
+	SingleDash SingleDashMode
+
+	// If it is true, a flag may be written as a prefix of a known flag or
+	// alias, if the prefix is unambiguous (like getopt_long).
+	//
+	// Example, for flags "verbose" and "version":
+	// `--verb` is `--verbose`, `--ver` gives the [AMBIGUOUS_FLAG] error.
+	Abbreviations bool
+
+	// The known flag names for [Parser.Abbreviations], [Parser.Load] adds
+	// flags of the struct.
+	KnownFlags []string
+
+	// It converts field names to flag names for fields without the `flag` tag,
+	// if it is nil [LegacySnakeCase] is used. It is overridden for a nested
+	// struct with the `naming` tag ("legacy", "snake", "kebab", "dot",
+	// "lowerCamel" or "exact") and for a struct, that implements [Namer].
+	//
+	// Set it to [SnakeCase] to keep acronyms together (`UserID` is "user_id"
+	// instead of "user_i_d").
+	//
+	// Fields of a struct are cached only for the package strategies (like
+	// [SnakeCase]), with other functions they are found on every insertion.
+	// Implement [Namer] on the struct to keep the cache with custom names.
+	//
+	// Example, for field `HTTPPort` with [KebabCase]:
+	// `--http-port`
+	Naming NameFunc
+
+	// If it is true, "-", "_", "." and case are ignored, when flags are
+	// matched with fields. Flag names and aliases of two fields mustn't be the
+	// same after it (see [NAME_COLLISION]).
+	//
+	// Example, for field `MaxConns`:
+	// `--max-conns`, `--max_conns` and `--MaxConns` are the same
+	Normalize bool
+
+	// If it isn't empty, every field without an `env` tag is also looked up in
+	// the environment as the upper cased flag name with this prefix.
+	//
+	// Example, prefix "APP" for field `Port`:
+	// `APP_PORT`
+	EnvPrefix string
+
+	// It is used to look up environment variables, if it is nil [os.LookupEnv] is used.
+	LookupEnv func(key string) (string, bool)
+
+	// It splits environment values for slices and arrays, if it is empty "," is used.
+	EnvSeparator string
+
+	// If it is true, values of string fields don't need brackets, values in
+	// brackets are still unquoted. A value, that starts with "-", has to be in
+	// brackets, otherwise it is parsed as a flag.
+	//
+	// Example, for field `Name`:
+	// `--name api` is the same as `--name 'api'`
+	UnquotedStrings bool
+
+	// If it isn't empty, it is the flag with a path to a JSON config file (see [ReadJSON]).
+	// Values from the file are used for flags, that aren't in the command line.
+	//
+	// Example, config flag "config":
+	// `--config config.json`
+	ConfigFlag string
+
+	// If it is true, `@path` arguments are replaced with arguments from the file (see [ExpandResponseFiles]).
+	ResponseFiles bool
+
+	// If it is true, flags, that aren't bound to any field (including fields
+	// of nested structs), give the [UNKNOWN_FLAG] errors. Otherwise they are
+	// ignored and returned in [Report.Unknown].
+	Strict bool
+
+	// If it is true, insertion stops on the first field with an error.
+	// Otherwise errors of all fields are returned together.
+	FailFast bool
+
+	// If it is true, fields with the `required:"true"` tag give the
+	// [REQUIRED_FLAG] error, if they aren't set, and values, that aren't in
+	// the `enum` tag, give the [NOT_ALLOWED] error. Otherwise the tags are
+	// only used in help text.
+	Validate bool
+
+	// The width of help text (see [Usage]), if it is zero, the COLUMNS
+	// environment variable or 80 is used.
+	Width int
+
+	// It completes values of flags in completion scripts (see [WriteBashCompletion]).
+	// The key is the flag name, the function gets the word being completed.
+	Completers map[string]func(prefix string) []string
+
+	// The output for answers to completion queries, if it is nil [os.Stdout] is used.
+	Output io.Writer
+
+	// It selects whether diagnostics are colored (see [WriteDiagnostics]).
+	Color ColorMode
+}
+
+func (p *Parser) lookupEnv(key string) (string, bool) {
+	if p.LookupEnv != nil {
+		return p.LookupEnv(key)
+	}
+	return os.LookupEnv(key)
+}
+
+func (p *Parser) envSeparator() string {
+	if p.EnvSeparator != "" {
+		return p.EnvSeparator
+	}
+	return ","
+}
@@ -0,0 +1,172 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// it replaces every `@path` argument with the arguments from the file.
+//
+// Arguments in the file are split by spaces and new lines. Text in brackets
+// (", ') is one argument even with spaces, brackets are kept, so string values
+// work as in the command line. A "\" outside of brackets escapes the next
+// character, in double brackets it escapes only `"` and "\". A "#" at the start of an argument starts a comment until the end
+// of the line.
+//
+// Example, file args.txt:
+//
+//	# server settings
+//	--host 'my host'
+//	--port 3700 @other.txt
+//
+// `@args.txt` is the same as `--host 'my host' --port 3700` and the
+// arguments from other.txt. Paths in files are relative to the file.
+//
+// Files including themselves (directly or not) give an error.
+func ExpandResponseFiles(args []string) ([]string, error) {
+	res := []string{}
+	for i, arg := range args {
+		if !isResponseFile(arg) {
+			res = append(res, arg)
+			continue
+		}
+
+		expanded, err := expandResponseFile(arg[1:], "arguments", i+1, nil)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, expanded...)
+	}
+
+	return res, nil
+}
+
+func isResponseFile(arg string) bool {
+	return len(arg) > 1 && strings.HasPrefix(arg, "@")
+}
+
+func expandResponseFile(path string, origin string, line int, stack []string) ([]string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, BAD_RESPONSE_FILE(origin, line, err)
+	}
+	for _, p := range stack {
+		if p == abs {
+			return nil, BAD_RESPONSE_FILE(origin, line, fmt.Sprintf("file %s includes itself", path))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, BAD_RESPONSE_FILE(origin, line, err)
+	}
+
+	tokens, lines, err := splitArgs(string(data))
+	if err != nil {
+		return nil, BAD_RESPONSE_FILE(path, lines[len(lines)-1], err)
+	}
+
+	stack = append(stack, abs)
+	res := []string{}
+	for i, token := range tokens {
+		if !isResponseFile(token) {
+			res = append(res, token)
+			continue
+		}
+
+		inner := token[1:]
+		if !filepath.IsAbs(inner) {
+			inner = filepath.Join(filepath.Dir(path), inner)
+		}
+		expanded, err := expandResponseFile(inner, path, lines[i], stack)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, expanded...)
+	}
+
+	return res, nil
+}
+
+// it splits text into arguments and returns the line of each argument.
+//
+// If there is an error, the last line is the line of the error.
+func splitArgs(s string) ([]string, []int, error) {
+	tokens := []string{}
+	lines := []int{}
+
+	var b strings.Builder
+	inToken := false
+	line := 1
+	start := 1
+	var mark rune
+
+	flush := func() {
+		if inToken {
+			tokens = append(tokens, b.String())
+			lines = append(lines, start)
+		}
+		b.Reset()
+		inToken = false
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\n' {
+			line++
+		}
+
+		switch {
+		case mark == '"' && r == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\'):
+			i++
+			b.WriteRune(runes[i])
+		case mark != 0:
+			b.WriteRune(r)
+			if r == mark {
+				mark = 0
+			}
+		case r == '\\':
+			if i+1 >= len(runes) {
+				return nil, append(lines, line), fmt.Errorf("escape at the end of the file")
+			}
+			if !inToken {
+				start = line
+			}
+			inToken = true
+			i++
+			if runes[i] == '\n' {
+				line++
+			}
+			b.WriteRune(runes[i])
+		case r == '"' || r == '\'':
+			if !inToken {
+				start = line
+			}
+			inToken = true
+			mark = r
+			b.WriteRune(r)
+		case r == '#' && !inToken:
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		default:
+			if !inToken {
+				start = line
+			}
+			inToken = true
+			b.WriteRune(r)
+		}
+	}
+
+	if mark != 0 {
+		return nil, append(lines, start), fmt.Errorf("bracket %c isn't closed", mark)
+	}
+	flush()
+
+	return tokens, lines, nil
+}
@@ -0,0 +1,70 @@
+package flags_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+func TestResponseFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "args.txt"), []byte("# server settings\n--host 'my host' # comment\n--port 3700 @other.txt\n"), 0o644); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "other.txt"), []byte(`--name "a \"b\"" with\ space`), 0o644); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	args, err := flags.ExpandResponseFiles([]string{"--debug", "@" + filepath.Join(dir, "args.txt"), "@"})
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	need := []string{"--debug", "--host", "'my host'", "--port", "3700", "--name", `"a "b""`, "with space", "@"}
+	if !slices.Equal(need, args) {
+		t.Fatalf("got different arguments: expected %q, got %q", need, args)
+	}
+
+	p := &flags.Parser{ResponseFiles: true}
+	val := new(Cfg)
+	if err := p.Load([]string{"@" + filepath.Join(dir, "args.txt")}, val); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if val.Host != "my host" || val.Port != 3700 {
+		t.Fatalf("got structure %+v", val)
+	}
+}
+
+func TestResponseFilesError(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.txt")
+	if err := os.WriteFile(first, []byte("--port 1\n\n@second.txt"), 0o644); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "second.txt"), []byte("@first.txt"), 0o644); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "open.txt"), []byte("--host\n'text"), 0o644); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	cases := map[string]string{
+		"@" + first:                             "second.txt:1",
+		"@" + filepath.Join(dir, "open.txt"):    "open.txt:2",
+		"@" + filepath.Join(dir, "missing.txt"): "arguments:1",
+	}
+	for arg, where := range cases {
+		_, err := flags.ExpandResponseFiles([]string{arg})
+		if !errors.Is(err, flags.BAD_RESPONSE_FILE()) {
+			t.Fatalf("got different errors expected %v, got %v", flags.BAD_RESPONSE_FILE(), err)
+		}
+		if !strings.Contains(err.Error(), where) {
+			t.Fatalf("error %q doesn't contain %q", err, where)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// The exit codes used by [Runner].
+const (
+	// The run function returned an error.
+	ExitFailure = 1
+	// The arguments can't be parsed or inserted.
+	ExitUsage = 2
+)
+
+// it runs a program: it loads the arguments into a struct, prints help text
+// and errors and exits with an exit code.
+//
+// The zero value is ready to use and works the same way as [Main].
+type Runner struct {
+	// The parser settings, if it is nil, the zero parser is used.
+	Parser *Parser
+	// The arguments, if it is nil, `os.Args[1:]` is used.
+	Args []string
+	// The output for help text, if it is nil [os.Stdout] is used.
+	Stdout io.Writer
+	// The output for errors, if it is nil [os.Stderr] is used.
+	Stderr io.Writer
+	// It exits the program, if it is nil [os.Exit] is used.
+	Exit func(code int)
+	// It returns the exit code for an error, if it is nil or returns 0,
+	// [ExitUsage] is used for errors of the arguments and [ExitFailure] for
+	// errors of the run function.
+	//
+	// Example, exit code 3 for required flags (see [Parser.Validate]):
+	//
+	//	func(err error) int {
+	//		if errors.Is(err, flags.REQUIRED_FLAG()) {
+	//			return 3
+	//		}
+	//		return 0
+	//	}
+	ExitCode func(err error) int
+}
+
+// it loads `os.Args[1:]` into a struct, runs the function and exits.
+//
+// If help is requested, it prints help text (see [Usage]) and exits with 0.
+// If the arguments can't be loaded, it prints the errors (see [Diagnostics])
+// and exits with [ExitUsage]. If the function returns an error, it prints the
+// error and exits with [ExitFailure]. Otherwise it exits with 0.
+func Main(v any, run func() error) {
+	new(Runner).Run(v, run)
+}
+
+// it loads the arguments into a struct, runs the function and exits with
+// the exit code (see [Main]).
+func (r *Runner) Run(v any, run func() error) {
+	r.exit(r.run(v, run))
+}
+
+func (r *Runner) run(v any, run func() error) int {
+	p := r.parser()
+	args := r.args()
+
+	err := p.Load(args, v)
+	switch {
+	case errors.Is(err, HELP()):
+		if err := p.WriteUsage(r.stdout(), v); err != nil {
+			return r.fail(err, ExitFailure)
+		}
+		return 0
+	case errors.Is(err, COMPLETE()):
+		return 0
+	case err != nil:
+		// The error is printed without diagnostics, if they can't be written.
+		if werr := p.WriteDiagnostics(r.stderr(), args, err); werr != nil {
+			return r.fail(err, ExitUsage)
+		}
+		return r.code(err, ExitUsage)
+	}
+
+	if run == nil {
+		return 0
+	}
+	if err := run(); err != nil {
+		return r.fail(err, ExitFailure)
+	}
+	return 0
+}
+
+// it prints the error and returns its exit code.
+func (r *Runner) fail(err error, def int) int {
+	fmt.Fprintf(r.stderr(), "error: %v\n", err)
+	return r.code(err, def)
+}
+
+// it returns the exit code for the error.
+func (r *Runner) code(err error, def int) int {
+	if r.ExitCode != nil {
+		if code := r.ExitCode(err); code != 0 {
+			return code
+		}
+	}
+	return def
+}
+
+func (r *Runner) parser() *Parser {
+	p := new(Parser)
+	if r.Parser != nil {
+		*p = *r.Parser
+	}
+	if p.Output == nil {
+		p.Output = r.stdout()
+	}
+	return p
+}
+
+func (r *Runner) args() []string {
+	if r.Args != nil {
+		return r.Args
+	}
+	return os.Args[1:]
+}
+
+func (r *Runner) stdout() io.Writer {
+	if r.Stdout != nil {
+		return r.Stdout
+	}
+	return os.Stdout
+}
+
+func (r *Runner) stderr() io.Writer {
+	if r.Stderr != nil {
+		return r.Stderr
+	}
+	return os.Stderr
+}
+
+func (r *Runner) exit(code int) {
+	if r.Exit != nil {
+		r.Exit(code)
+		return
+	}
+	os.Exit(code)
+}
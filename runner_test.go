@@ -0,0 +1,85 @@
+package flags_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+func TestRunner(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		args   string
+		run    error
+		code   int
+		stdout string
+		stderr string
+	}{
+		{args: "--port 80", code: 0},
+		{args: "--help", code: 0, stdout: "Flags:"},
+		{args: "--port x", code: flags.ExitUsage, stderr: "hint: expected int"},
+		{args: "--level 'warn'", code: 3, stderr: "allowed values: debug, info"},
+		{args: "--port 80", run: errFailed, code: flags.ExitFailure, stderr: "error: failed"},
+	}
+
+	for _, test := range tests {
+		var stdout, stderr strings.Builder
+		code := -1
+		r := &flags.Runner{
+			Parser: &flags.Parser{Validate: true},
+			Args:   strings.Fields(test.args),
+			Stdout: &stdout,
+			Stderr: &stderr,
+			Exit:   func(c int) { code = c },
+			ExitCode: func(err error) int {
+				if errors.Is(err, flags.NOT_ALLOWED()) {
+					return 3
+				}
+				return 0
+			},
+		}
+
+		val := new(withErrors)
+		r.Run(val, func() error { return test.run })
+		if code != test.code {
+			t.Fatalf("%q: got exit code %d, expected %d (stderr %q)", test.args, code, test.code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), test.stdout) || !strings.Contains(stderr.String(), test.stderr) {
+			t.Fatalf("%q: got stdout %q and stderr %q", test.args, stdout.String(), stderr.String())
+		}
+		if test.code == 0 && test.stdout == "" && val.Port != 80 {
+			t.Fatalf("%q: got port %d", test.args, val.Port)
+		}
+	}
+}
+
+// it fails on the first write.
+type failingWriter struct {
+	strings.Builder
+	failed bool
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if !w.failed {
+		w.failed = true
+		return 0, errors.New("write failed")
+	}
+	return w.Builder.Write(b)
+}
+
+func TestRunnerDiagnosticsError(t *testing.T) {
+	stderr := new(failingWriter)
+	code := -1
+	r := &flags.Runner{
+		Args:   strings.Fields("--port x"),
+		Stderr: stderr,
+		Exit:   func(c int) { code = c },
+	}
+
+	r.Run(new(withErrors), nil)
+	if code != flags.ExitUsage || !strings.HasPrefix(stderr.String(), "error: ") {
+		t.Fatalf("got exit code %d and stderr %q", code, stderr.String())
+	}
+}
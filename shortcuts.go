@@ -0,0 +1,179 @@
+package flags
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/vandi37/flags/internal/tags"
+)
+
+// it selects how arguments with a single dash are parsed (see [Parser.SingleDash]).
+type SingleDashMode int
+
+const (
+	// Every character is a shortcut, `-vx` is the same as `-v -x` (POSIX clustering).
+	SingleDashShortcuts SingleDashMode = iota
+	// An argument with more than one character is a flag name or an alias,
+	// `-Xmx` is the same as `--Xmx`. Arguments with a single character are shortcuts.
+	SingleDashNames
+)
+
+// it returns the shortcuts of a struct declared in tags.
+//
+// The shortcut is taken from the `short` tag, from the `flag` tag after a
+// comma and from aliases with a single character (see [TagAliases]), fields
+// of nested structs are included.
+//
+// Example, shortcut 'p' for flag "port":
+//
+//	Port int `flag:"port" short:"p"`
+//	Port int `flag:"port,p"`
+//	Port int `flag:",p"`
+//	Port int `alias:"p"`
+//
+// It returns the [BAD_SHORTCUT] error if a shortcut isn't a single character
+// and the [SHORTCUT_CONFLICT] error if a shortcut is used for different flags.
+func TagShortcuts(v any) (map[rune]string, error) {
+	return new(Parser).TagShortcuts(v)
+}
+
+// it returns the shortcuts of a struct declared in tags for flag names of
+// the parser (see [TagShortcuts] and [Parser.Naming]).
+func (p *Parser) TagShortcuts(v any) (map[rune]string, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return make(map[rune]string), nil
+	}
+
+	errs := []error{}
+	fields := []tags.Field{}
+	for _, field := range p.flagFields(t) {
+		shortcuts, err := fieldShortcuts(field)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fields = append(fields, tags.Field{Flag: field.name, Shortcuts: shortcuts})
+	}
+	res := tags.ShortcutMap(fields, func(s rune, first string, second string) {
+		errs = append(errs, SHORTCUT_CONFLICT(s, first, second))
+	})
+
+	if len(errs) > 0 {
+		return nil, mega("got some errors", errs)
+	}
+	return res, nil
+}
+
+// it returns the aliases of a struct declared in tags.
+//
+// Aliases are other names of a flag from the comma separated `alias` tag, the
+// prefix of the nested struct is added to them. Aliases with a single
+// character are shortcuts (see [TagShortcuts]).
+//
+// Example, flags "debug-output" and "verbose" and shortcut 'v' for field `Verbose`:
+//
+//	Verbose bool `alias:"debug-output,v"`
+//
+// It returns the [ALIAS_CONFLICT] error if an alias is used for different
+// flags or is the name of another flag.
+func TagAliases(v any) (map[string]string, error) {
+	return new(Parser).TagAliases(v)
+}
+
+// it returns the aliases of a struct declared in tags for flag names of the
+// parser (see [TagAliases] and [Parser.Naming]).
+func (p *Parser) TagAliases(v any) (map[string]string, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return make(map[string]string), nil
+	}
+
+	fields := []tags.Field{}
+	for _, field := range p.flagFields(t) {
+		fields = append(fields, tags.Field{Flag: field.name, Aliases: fieldAliases(field)})
+	}
+
+	errs := []error{}
+	res := tags.AliasMap(fields, func(alias string, first string, second string) {
+		errs = append(errs, ALIAS_CONFLICT(alias, first, second))
+	})
+
+	if len(errs) > 0 {
+		return nil, mega("got some errors", errs)
+	}
+	return res, nil
+}
+
+// it returns a copy of the parser with shortcuts and aliases from tags of the
+// struct (see [TagShortcuts] and [TagAliases]), [Parser.Shortcuts] and
+// [Parser.Aliases] override them.
+func (p *Parser) withTags(v any) (*Parser, error) {
+	shortcuts, err := p.TagShortcuts(v)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := p.TagAliases(v)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := *p
+	cp.Shortcuts = shortcuts
+	maps.Copy(cp.Shortcuts, p.Shortcuts)
+	cp.Aliases = aliases
+	maps.Copy(cp.Aliases, p.Aliases)
+	cp.KnownFlags = append(slices.Clone(p.KnownFlags), p.flagNames(v)...)
+	return &cp, nil
+}
+
+// it returns the flag names of a struct.
+func (p *Parser) flagNames(v any) []string {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil
+	}
+
+	res := []string{}
+	for _, field := range p.flagFields(t) {
+		res = append(res, field.name)
+	}
+	return res
+}
+
+// it returns the flag name for the name, the alias or the abbreviation (see
+// [Parser.Abbreviations]).
+func (p *Parser) resolve(name string) (string, error) {
+	if fl, ok := p.Aliases[name]; ok {
+		return fl, nil
+	}
+	if !p.Abbreviations || slices.Contains(p.KnownFlags, name) {
+		return name, nil
+	}
+
+	found := []string{}
+	forms := []string{}
+	for _, fl := range slices.Sorted(slices.Values(p.KnownFlags)) {
+		if strings.HasPrefix(fl, name) && !slices.Contains(found, fl) {
+			found = append(found, fl)
+			forms = append(forms, "--"+fl)
+		}
+	}
+	for _, alias := range slices.Sorted(maps.Keys(p.Aliases)) {
+		if fl := p.Aliases[alias]; strings.HasPrefix(alias, name) && !slices.Contains(found, fl) {
+			found = append(found, fl)
+			forms = append(forms, "--"+alias)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return name, nil
+	case 1:
+		return found[0], nil
+	default:
+		return "", AMBIGUOUS_FLAG(name, strings.Join(forms, ", "))
+	}
+}
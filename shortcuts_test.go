@@ -0,0 +1,140 @@
+package flags_test
+
+import (
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+type withShortcuts struct {
+	Port    int  `flag:"port,p"`
+	Verbose bool `short:"v"`
+	Db      struct {
+		Host string `flag:",H"`
+	} `prefix:"db"`
+}
+
+func TestTagShortcuts(t *testing.T) {
+	shortcuts, err := flags.TagShortcuts(new(withShortcuts))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	need := map[rune]string{'p': "port", 'v': "verbose", 'H': "db.host"}
+	if !maps.Equal(shortcuts, need) {
+		t.Fatalf("got shortcuts %v, expected %v", shortcuts, need)
+	}
+
+	val := new(withShortcuts)
+	if err := flags.Load(strings.Fields("-pv 80 -H 'localhost'"), val); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if val.Port != 80 || !val.Verbose || val.Db.Host != "localhost" {
+		t.Fatalf("got %+v", *val)
+	}
+
+	val = new(withShortcuts)
+	p := &flags.Parser{Shortcuts: map[rune]string{'p': "db.host"}}
+	if err := p.Load(strings.Fields("-p 'localhost'"), val); err != nil || val.Db.Host != "localhost" {
+		t.Fatalf("got %+v and error %v", *val, err)
+	}
+	if usage := flags.Usage(new(withShortcuts)); !strings.Contains(usage, "-p, --port int") {
+		t.Fatalf("got usage:\n%s", usage)
+	}
+}
+
+func TestTagShortcutsErrors(t *testing.T) {
+	type conflict struct {
+		Port int `short:"p"`
+		Db   struct {
+			Port int `short:"p"`
+		} `prefix:"db"`
+	}
+	if err := flags.Load(nil, new(conflict)); !errors.Is(err, flags.SHORTCUT_CONFLICT()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.SHORTCUT_CONFLICT(), err)
+	}
+
+	type bad struct {
+		Port int `short:"port"`
+	}
+	if _, err := flags.TagShortcuts(new(bad)); !errors.Is(err, flags.BAD_SHORTCUT()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.BAD_SHORTCUT(), err)
+	}
+}
+
+type withAliases struct {
+	Verbose bool `alias:"debug-output,v"`
+	Memory  string
+	Db      struct {
+		Host string `alias:"hostname"`
+	} `prefix:"db"`
+}
+
+func TestAliases(t *testing.T) {
+	val := new(withAliases)
+	if err := flags.Load(strings.Fields("--debug-output --db.hostname 'localhost'"), val); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if !val.Verbose || val.Db.Host != "localhost" {
+		t.Fatalf("got %+v", *val)
+	}
+
+	if err := flags.Load(strings.Fields("-v --verbose"), new(withAliases)); !errors.Is(err, flags.TWICE_FLAG()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.TWICE_FLAG(), err)
+	}
+
+	val = new(withAliases)
+	p := &flags.Parser{SingleDash: flags.SingleDashNames, Aliases: map[string]string{"Xmx": "memory"}}
+	if err := p.Load(strings.Fields("-Xmx '512m' -v"), val); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if !val.Verbose || val.Memory != "512m" {
+		t.Fatalf("got %+v", *val)
+	}
+
+	if err := flags.Load(strings.Fields("-Xmx '512m'"), new(withAliases)); !errors.Is(err, flags.WRONG_SHORTCUT()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.WRONG_SHORTCUT(), err)
+	}
+	if usage := flags.Usage(new(withAliases)); !strings.Contains(usage, "-v, --verbose, --debug-output") {
+		t.Fatalf("got usage:\n%s", usage)
+	}
+
+	type conflict struct {
+		Verbose bool `alias:"debug"`
+		Debug   bool
+	}
+	if err := flags.Load(nil, new(conflict)); !errors.Is(err, flags.ALIAS_CONFLICT()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.ALIAS_CONFLICT(), err)
+	}
+}
+
+func TestAbbreviations(t *testing.T) {
+	type withVersion struct {
+		Verbose bool `alias:"debug-output"`
+		Version bool
+	}
+
+	p := &flags.Parser{Abbreviations: true}
+	val := new(withVersion)
+	if err := p.Load(strings.Fields("--verb --debug"), val); !errors.Is(err, flags.TWICE_FLAG()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.TWICE_FLAG(), err)
+	}
+	if err := p.Load(strings.Fields("--vers"), val); err != nil || !val.Version {
+		t.Fatalf("got %+v and error %v", *val, err)
+	}
+
+	err := p.Load(strings.Fields("--ver"), new(withVersion))
+	if !errors.Is(err, flags.AMBIGUOUS_FLAG()) || !strings.Contains(err.Error(), "candidates: --verbose, --version") {
+		t.Fatalf("got different errors expected %v, got %v", flags.AMBIGUOUS_FLAG(), err)
+	}
+
+	f, err := (&flags.Parser{Abbreviations: true, KnownFlags: []string{"port"}}).Parse(strings.Fields("--po 80"))
+	if err != nil || len(f["port"]) != 1 {
+		t.Fatalf("got %v and error %v", f, err)
+	}
+	if err := flags.Load(strings.Fields("--verb"), new(withVersion)); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package flags_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vandi37/flags"
+)
+
+func TestStrict(t *testing.T) {
+	p := &flags.Parser{Strict: true}
+
+	err := p.Load(strings.Fields("--num 37 --prot 80 --str 'be' --hots x"), new(inside))
+	for _, name := range []string{"prot", "hots"} {
+		if !errors.Is(err, flags.UNKNOWN_FLAG()) || !strings.Contains(err.Error(), "--"+name) {
+			t.Fatalf("got error %v, expected unknown flag --%s", err, name)
+		}
+	}
+
+	if err := p.Load(strings.Fields("--num 37 --str 'be'"), new(inside)); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+}
+
+func TestLeftovers(t *testing.T) {
+	report, err := new(flags.Parser).LoadReport(strings.Fields("--zeta 1 --port 80 --child_flag a b --alpha"), new(Cfg))
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	need := strings.Fields("--zeta 1 --child_flag a b --alpha")
+	if !slices.Equal(need, report.Unknown) {
+		t.Fatalf("got different arguments: expected %q, got %q", need, report.Unknown)
+	}
+}
+
+func TestStrictCommand(t *testing.T) {
+	a := newApp()
+	a.cmd.Parser.Strict = true
+
+	if err := a.cmd.Execute(strings.Fields("serve --verbose --port 1")); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+	if err := a.cmd.Execute(strings.Fields("serve --steps 1")); !errors.Is(err, flags.UNKNOWN_FLAG()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.UNKNOWN_FLAG(), err)
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	p := &flags.Parser{Strict: true, Shortcuts: map[rune]string{'p': "port"}}
+
+	err := p.Load(strings.Fields("--prot 80 --hots 'x' --db.hots 'y'"), new(withPrefixes))
+	need := []string{
+		"unknown flag --db.hots, did you mean --db.host?",
+		"unknown flag --hots, did you mean --host?",
+		"unknown flag --prot, did you mean --port?",
+	}
+	for _, line := range need {
+		if err == nil || !strings.Contains(err.Error(), line) {
+			t.Fatalf("error %v doesn't contain %q", err, line)
+		}
+	}
+
+	var unknown *flags.UnknownFlagError
+	if !errors.As(err, &unknown) || unknown.Flag != "db.hots" || !slices.Equal(unknown.Suggestions, []string{"--db.host"}) {
+		t.Fatalf("got error %+v", unknown)
+	}
+
+	err = p.Load(strings.Fields("-P 80"), new(withPrefixes))
+	var shortcut *flags.UnknownShortcutError
+	if !errors.As(err, &shortcut) || !errors.Is(err, flags.WRONG_SHORTCUT()) || !slices.Equal(shortcut.Suggestions, []string{"-p"}) {
+		t.Fatalf("got error %v", err)
+	}
+
+	err = p.Load(strings.Fields("-prot 80"), new(withPrefixes))
+	if !errors.As(err, &shortcut) || shortcut.Shortcut != 'r' || !slices.Equal(shortcut.Suggestions, []string{"--port"}) {
+		t.Fatalf("got error %v", err)
+	}
+	if need := "shortcut 'r' does not exist, did you mean --port?"; !strings.Contains(err.Error(), need) {
+		t.Fatalf("error %v doesn't contain %q", err, need)
+	}
+}
+
+type withPrefixes struct {
+	Port int
+	Host string
+	Db   struct {
+		Host string
+	} `prefix:"db"`
+}
@@ -1,10 +1,11 @@
 package flags
 
 import (
-	"fmt"
+	"errors"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
-	"time"
 	"unsafe"
 )
 
@@ -20,77 +21,242 @@ import (
 // The struct fields can be tagged with `flag:"<flag_name>"` to explicitly
 // specify the flag name associated with a field. If a field doesn't have
 // a `flag` tag, the function automatically converts the field name from
-// CamelCase to snake_case to match the expected flag name. Fields with the
-// `flag:"-"` tag are ignored.
+// CamelCase to snake_case to match the expected flag name (every upper case
+// letter starts a word, `MaxConns` is "max_conns" and `HTTPPort` is
+// "h_t_t_p_port", see [Parser.Naming] for other strategies, [SnakeCase]
+// keeps acronyms together). Fields with the `flag:"-"` tag are ignored.
 //
-// If an error occurs during the insertion process (e.g., a type mismatch),
-// it will return an error.
+// Fields of nested structs use the same flag names as the other fields. If
+// a nested struct field is tagged with `prefix:"<prefix>"`, the flag names
+// of its fields start with the prefix and a dot.
+//
+// Example, prefix "db" for field `Host` of a nested struct:
+// `--db.host`
+//
+// A field tagged with `required:"true"` must be set. A field tagged with
+// `enum:"<value>,<value>..."` accepts only the listed values (brackets of
+// string values are ignored).
+//
+// If a flag is absent, a field tagged with `env:"<VARIABLE>"` is filled
+// from that environment variable instead. Values for slices and arrays are
+// split by ",". Command-line flags always take precedence.
+//
+// Flags, that aren't bound to any field, are ignored (see [Parser.Strict]).
+//
+// If errors occur during the insertion process (e.g., a type mismatch), it
+// goes on with other fields and returns all errors together, each error has
+// the path of its field (see [Parser.FailFast]).
 //
 // Full flag values parsing rules are in readme
 func Insert(flags map[string][]string, v any) error {
+	return new(Parser).Insert(flags, v)
+}
+
+// it inserts values from parsed flags into a struct using the parser settings.
+//
+// It works the same way as [Insert], but environment variables are looked up
+// with [Parser.LookupEnv], and fields without an `env` tag are also looked up
+// using [Parser.EnvPrefix]. If [Parser.Strict] is true, flags, that aren't
+// bound to any field, give the [UNKNOWN_FLAG] errors.
+func (p *Parser) Insert(flags map[string][]string, v any) error {
+	_, err := p.insertSources(nil, v, Source{Name: argumentsSource, Flags: flags, OverrideEnv: true})
+	return err
+}
+
+// it holds the state of one insertion.
+type inserting struct {
+	flags   map[string][]string
+	origins map[string]origin
+	report  *Report
+	// The flag names of all fields.
+	bound map[string]bool
+	// Errors of fields (see [Parser.FailFast]).
+	errs []error
+	// Positions of flags in the arguments, it may be nil.
+	pos positions
+}
+
+func (p *Parser) insertReport(flags map[string][]string, origins map[string]origin, pos positions, v any) (*Report, error) {
 	rv := reflect.ValueOf(v)
 	if rv.Kind() != reflect.Ptr || rv.IsNil() {
-		return TYPE_ERROR()
+		return nil, TYPE_ERROR()
 	}
 
 	rv = rv.Elem()
 	rt := rv.Type()
 
-	return insert(flags, rv, rt)
+	if p.Normalize {
+		var err error
+		if flags, origins, pos, err = p.normalizeFlags(flags, origins, pos, rt); err != nil {
+			return nil, err
+		}
+	}
+
+	st := &inserting{flags: flags, origins: origins, report: new(Report), bound: make(map[string]bool), pos: pos}
+	if err := p.insert(st, rv); err != nil {
+		return nil, err
+	}
+
+	unknown := make(map[string][]string)
+	for name, args := range flags {
+		if !st.bound[name] && name != p.ConfigFlag {
+			unknown[name] = args
+		}
+	}
+	st.report.Unknown = unknownArgs(unknown, pos)
+	if p.Strict && len(unknown) > 0 {
+		st.errs = append(st.errs, unknownFlags(unknown, p.flagCandidates(slices.Sorted(maps.Keys(st.bound))), pos)...)
+	}
+
+	if len(st.errs) > 0 {
+		return nil, mega("got some errors", st.errs)
+	}
+
+	return st.report, nil
+}
+
+// it returns flags, that aren't bound to any field, as arguments in the order
+// of the command line, flags without positions are sorted by name.
+func unknownArgs(unknown map[string][]string, pos positions) []string {
+	names := slices.Sorted(maps.Keys(unknown))
+	slices.SortStableFunc(names, func(a, b string) int {
+		return pos.flag(a) - pos.flag(b)
+	})
+
+	res := []string{}
+	for _, name := range names {
+		res = append(res, "--"+name)
+		res = append(res, unknown[name]...)
+	}
+	return res
+}
+
+// it returns errors for flags, that aren't bound to any field, with
+// suggestions from the candidates (see [Parser.flagCandidates]).
+func unknownFlags(unknown map[string][]string, candidates []string, pos positions) []error {
+	errs := []error{}
+	for _, name := range slices.Sorted(maps.Keys(unknown)) {
+		errs = append(errs, &UnknownFlagError{Flag: name, Suggestions: suggest("--"+name, candidates), Position: pos.flag(name)})
+	}
+	return errs
+}
+
+// it records an error of a field, it returns the error if the insertion has
+// to stop (see [Parser.FailFast]).
+func (p *Parser) fieldError(st *inserting, path string, flag string, err error) error {
+	err = withField(err, flag, path, st.pos, st.flags[flag])
+	if p.FailFast {
+		return err
+	}
+
+	st.errs = append(st.errs, err)
+	return nil
+}
+
+// it adds the flag, the field and the position to [ConversionError],
+// [ArityError] and [NotAllowedError], other errors are wrapped with [FieldError].
+func withField(err error, flag string, path string, pos positions, args []string) error {
+	var conv *ConversionError
+	var arity *ArityError
+	var enum *NotAllowedError
+	switch {
+	case errors.As(err, &conv):
+		conv.Flag, conv.Field = flag, path
+		conv.Position = pos.value(flag, args, conv.Value)
+	case errors.As(err, &enum):
+		enum.Field = path
+		enum.Position = pos.value(flag, args, enum.Value)
+	case errors.As(err, &arity):
+		arity.Flag, arity.Field = flag, path
+		arity.Position = pos.flag(flag)
+	default:
+		err = &FieldError{Flag: flag, Field: path, Position: pos.flag(flag), Err: err}
+	}
+	return err
 }
 
-func insert(flags map[string][]string, v reflect.Value, t reflect.Type) error {
+func (p *Parser) insert(st *inserting, v reflect.Value) error {
 	if v.Kind() != reflect.Struct {
 		return IS_NOT_A_STRUCT()
 	}
 
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		fieldType := t.Field(i)
-		fieldName := fieldType.Tag.Get("flag")
-		if fieldName == "-" {
-			continue
-		}
-		if fieldName == "" {
-			fieldName = camelToSnake(fieldType.Name)
-		}
+	for _, fieldType := range p.flagFields(v.Type()) {
+		field := fieldByIndex(v, fieldType.index)
+		fieldName := fieldType.name
+		fieldPath := fieldType.path
 
-		_, ok := field.Interface().(time.Time)
-
-		if !ok && field.Kind() == reflect.Struct {
-			if err := insert(flags, field, fieldType.Type); err != nil {
-				return err
+		st.bound[fieldName] = true
+		origin := FieldOrigin{Field: fieldPath, Flag: fieldName}
+		// Environment variables override sources without OverrideEnv.
+		args, exist := st.flags[fieldName]
+		source := st.origins[fieldName]
+		origin.Source = source.name
+		if !exist || !source.overrideEnv {
+			if envArgs, env, ok := p.env(fieldType.StructField, fieldName); ok {
+				args, exist = envArgs, true
+				origin.Source = "env " + env
 			}
-			continue
 		}
-
-		if !ok && field.Kind() == reflect.Pointer && field.Elem().Kind() == reflect.Struct {
-			if field.IsNil() {
-				field.Set(reflect.New(field.Type().Elem()))
-			}
-			if err := insert(flags, field.Elem(), fieldType.Type.Elem()); err != nil {
-				return err
+		if p.UnquotedStrings {
+			args = quoteStrings(args, fieldType.Type)
+		}
+		if !exist || !field.CanSet() || args == nil {
+			if p.Validate && fieldType.required {
+				if err := p.fieldError(st, fieldPath, fieldName, REQUIRED_FLAG(fieldName)); err != nil {
+					return err
+				}
 			}
+			st.report.Fields = append(st.report.Fields, FieldOrigin{Field: fieldPath, Flag: fieldName})
 			continue
 		}
+		origin.Values = args
+		st.report.Fields = append(st.report.Fields, origin)
 
-		args, exist := flags[fieldName]
-		if !exist || !field.CanSet() || args == nil {
-			continue
+		var err error
+		if p.Validate {
+			err = checkEnum(fieldType.enum, args, fieldName)
+		}
+		if err == nil && fieldType.isTime {
+			err = setTime(field, args, fieldName)
+		} else if err == nil {
+			err = setValue(args, field, fieldName)
 		}
-		if ok {
-			if err := setTime(field, args, fieldName); err != nil {
+		if err != nil {
+			if err := p.fieldError(st, fieldPath, fieldName, err); err != nil {
 				return err
 			}
-			continue
 		}
+	}
+	return nil
+}
 
-		if err := setValue(args, field, fieldName); err != nil {
-			return err
+// it quotes values of string fields, that aren't quoted yet (see
+// [Parser.UnquotedStrings]), other values are kept.
+func quoteStrings(args []string, t reflect.Type) []string {
+	t = indirectType(t)
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = indirectType(t.Elem())
+	}
+	if t.Kind() != reflect.String || args == nil {
+		return args
+	}
+
+	res := make([]string, len(args))
+	for i, arg := range args {
+		if _, ok := convertString(arg); !ok {
+			arg = quoteString(arg)
 		}
+		res[i] = arg
+	}
+	return res
+}
 
+// it returns the prefix for fields of a nested struct.
+func nestedPrefix(prefix string, field reflect.StructField) string {
+	if p := field.Tag.Get("prefix"); p != "" {
+		return prefix + p + "."
 	}
-	return nil
+	return prefix
 }
 
 func setValue(args []string, field reflect.Value, fieldName string) error {
@@ -101,11 +267,11 @@ func setValue(args []string, field reflect.Value, fieldName string) error {
 			return nil
 		}
 		if len(args) != 1 {
-			return TOO_MANY_ARGUMENTS(fieldName)
+			return arityError(fieldName, len(args), 1)
 		}
 
-		if b, err := strconv.ParseBool(args[0]); err != nil {
-			return CANT_CONVERT(args[0], "bool")
+		if b, err := convertBoolArg(args[0]); err != nil {
+			return err
 		} else {
 			field.SetBool(b)
 		}
@@ -155,67 +321,67 @@ func setValue(args []string, field reflect.Value, fieldName string) error {
 		}
 	case reflect.Float32:
 		if len(args) != 1 {
-			return TOO_MANY_ARGUMENTS(fieldName)
+			return arityError(fieldName, len(args), 1)
 		}
 
-		if f, err := strconv.ParseFloat(args[0], 32); err != nil {
-			return CANT_CONVERT(args[0], "float32")
+		if f, err := convertFloatArg(args[0], 32); err != nil {
+			return err
 		} else {
 			field.SetFloat(f)
 		}
 	case reflect.Float64:
 		if len(args) != 1 {
-			return TOO_MANY_ARGUMENTS(fieldName)
+			return arityError(fieldName, len(args), 1)
 		}
 
-		if f, err := strconv.ParseFloat(args[0], 64); err != nil {
-			return CANT_CONVERT(args[0], "float64")
+		if f, err := convertFloatArg(args[0], 64); err != nil {
+			return err
 		} else {
 			field.SetFloat(f)
 		}
 	case reflect.Complex64:
 		if len(args) != 1 {
-			return TOO_MANY_ARGUMENTS(fieldName)
+			return arityError(fieldName, len(args), 1)
 		}
 
-		if c, err := strconv.ParseComplex(args[0], 64); err != nil {
-			return CANT_CONVERT(args[0], "complex64")
+		if c, err := convertComplexArg(args[0], 64); err != nil {
+			return err
 		} else {
 			field.SetComplex(c)
 		}
 	case reflect.Complex128:
 		if len(args) != 1 {
-			return TOO_MANY_ARGUMENTS(fieldName)
+			return arityError(fieldName, len(args), 1)
 		}
 
-		if c, err := strconv.ParseComplex(args[0], 128); err != nil {
-			return CANT_CONVERT(args[0], "complex128")
+		if c, err := convertComplexArg(args[0], 128); err != nil {
+			return err
 		} else {
 			field.SetComplex(c)
 		}
 	case reflect.UnsafePointer:
 		if len(args) != 1 {
-			return TOO_MANY_ARGUMENTS(fieldName)
+			return arityError(fieldName, len(args), 1)
 		}
 
 		if n, ok := convertUint(args[0], 64); !ok {
-			return CANT_CONVERT(args[0], "unsafe.Pointer")
+			return conversionError(args[0], "unsafe.Pointer", nil)
 		} else {
 			field.SetPointer(unsafe.Pointer(uintptr(n)))
 		}
 	case reflect.String:
 		if len(args) != 1 {
-			return TOO_MANY_ARGUMENTS(fieldName)
+			return arityError(fieldName, len(args), 1)
 		}
 
-		if s, ok := convertString(args[0]); !ok {
-			return CANT_CONVERT(args[0], "string")
+		if s, err := convertStringArg(args[0]); err != nil {
+			return err
 		} else {
 			field.SetString(s)
 		}
 	case reflect.Array:
 		if field.Len() < len(args) {
-			return TOO_MANY_ARGUMENTS(fieldName)
+			return arityError(fieldName, len(args), field.Len())
 		}
 
 		for i := 0; i < len(args); i++ {
@@ -249,7 +415,7 @@ func setValue(args []string, field reflect.Value, fieldName string) error {
 		for _, arg := range args {
 			var conv = defaultConvert(arg)
 			if conv == nil {
-				return CANT_DEFAULT_CONVERT(arg)
+				return conversionError(arg, "interface {}", nil)
 			}
 			vals = append(vals, conv)
 		}
@@ -274,11 +440,11 @@ func setValue(args []string, field reflect.Value, fieldName string) error {
 
 func setInt(val reflect.Value, fieldName string, args []string, size int) error {
 	if len(args) != 1 {
-		return TOO_MANY_ARGUMENTS(fieldName)
+		return arityError(fieldName, len(args), 1)
 	}
 
-	if n, ok := convertInt(args[0], size); !ok {
-		return CANT_CONVERT(args[0], fmt.Sprint("int", size))
+	if n, err := convertIntArg(args[0], size, val.Type().String()); err != nil {
+		return err
 	} else {
 		val.SetInt(n)
 	}
@@ -288,11 +454,11 @@ func setInt(val reflect.Value, fieldName string, args []string, size int) error
 
 func setUint(val reflect.Value, fieldName string, args []string, size int) error {
 	if len(args) != 1 {
-		return TOO_MANY_ARGUMENTS(fieldName)
+		return arityError(fieldName, len(args), 1)
 	}
 
-	if n, ok := convertUint(args[0], size); !ok {
-		return CANT_CONVERT(args[0], fmt.Sprint("uint", size))
+	if n, err := convertUintArg(args[0], size, val.Type().String()); err != nil {
+		return err
 	} else {
 		val.SetUint(n)
 	}
@@ -163,3 +163,27 @@ func TestInside(t *testing.T) {
 		t.Fatalf("got structure %+v, expected %+v", val, need)
 	}
 }
+
+type unquoted struct {
+	Name  string
+	Tags  []string
+	Label *string
+	Count int
+}
+
+func TestUnquotedStrings(t *testing.T) {
+	p := &flags.Parser{UnquotedStrings: true}
+	val := new(unquoted)
+	err := p.Load([]string{"--name", "api", "--tags", "a", "'-b'", "--label", "'x y'", "--count", "2"}, val)
+	if err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	if val.Name != "api" || !slices.Equal(val.Tags, []string{"a", "-b"}) || val.Label == nil || *val.Label != "x y" || val.Count != 2 {
+		t.Fatalf("got structure %+v", val)
+	}
+
+	if err := flags.Load(strings.Fields("--name api"), new(unquoted)); err == nil {
+		t.Fatalf("expected an error without UnquotedStrings")
+	}
+}
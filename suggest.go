@@ -0,0 +1,110 @@
+package flags
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// it returns candidates, that are close to the name, the closest are first.
+//
+// A candidate is close, if the edit distance (see [editDistance]) is at most a third
+// of the name length (at least 1), or if the name is a prefix of it.
+func suggest(name string, candidates []string) []string {
+	limit := max(len([]rune(name))/3, 1)
+
+	type scored struct {
+		name string
+		dist int
+	}
+	found := []scored{}
+	for _, c := range candidates {
+		if c == name || slices.ContainsFunc(found, func(s scored) bool { return s.name == c }) {
+			continue
+		}
+		d := editDistance(name, c)
+		if d <= limit || (len(name) > 1 && len(c) > len(name) && c[:len(name)] == name) {
+			found = append(found, scored{c, d})
+		}
+	}
+
+	slices.SortStableFunc(found, func(a, b scored) int { return a.dist - b.dist })
+
+	res := make([]string, len(found))
+	for i, s := range found {
+		res[i] = s.name
+	}
+	return res
+}
+
+// it returns the edit distance between two strings, insertions, deletions,
+// replacements and swaps of two neighbour characters cost 1.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
+
+// it formats suggestions for an error message.
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return ", did you mean " + strings.Join(suggestions, " or ") + "?"
+}
+
+// it returns flags, aliases and shortcuts of the parser as they are written
+// in the command line.
+func (p *Parser) flagCandidates(names []string) []string {
+	res := []string{}
+	for _, name := range names {
+		res = append(res, "--"+name)
+	}
+	for _, alias := range slices.Sorted(maps.Keys(p.Aliases)) {
+		res = append(res, "--"+alias)
+	}
+	for _, s := range slices.Sorted(maps.Keys(p.Shortcuts)) {
+		res = append(res, "-"+string(s))
+	}
+	return res
+}
+
+// it returns suggestions for an unknown shortcut in the argument: shortcuts,
+// that are the same in other case, then flags, aliases and shortcuts close to
+// the argument written as a flag (e.g. "--port" for "-prot").
+func (p *Parser) suggestShortcut(s rune, arg string) []string {
+	res := []string{}
+	for _, other := range slices.Sorted(maps.Keys(p.Shortcuts)) {
+		if other != s && unicode.ToLower(other) == unicode.ToLower(s) {
+			res = append(res, "-"+string(other))
+		}
+	}
+	for _, c := range suggest("--"+strings.TrimPrefix(arg, "-"), p.flagCandidates(p.KnownFlags)) {
+		if !slices.Contains(res, c) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
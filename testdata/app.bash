@@ -0,0 +1,41 @@
+# bash completion for app
+_app_complete_reply() {
+    local cur="$1" word
+    shift
+    COMPREPLY=()
+    for word in "$@"; do
+        local quoted
+        quoted="$(printf '%q' "$word")"
+        if [[ "$word" == "$cur"* || "$quoted" == "$cur"* ]]; then
+            COMPREPLY+=("$quoted")
+        fi
+    done
+}
+
+_app_complete() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"
+    case "$prev" in
+    '--env'|'-e')
+        local IFS=$'\n'
+        _app_complete_reply "$cur" $('app' __complete "${COMP_WORDS[@]:1:COMP_CWORD}")
+        return
+        ;;
+    '--level')
+        _app_complete_reply "$cur" ''\''debug'\''' ''\''info'\'''
+        return
+        ;;
+    '--workers')
+        _app_complete_reply "$cur" '1' '2' '4'
+        return
+        ;;
+    '--config')
+        COMPREPLY=($(compgen -f -- "$cur"))
+        return
+        ;;
+    esac
+    if [[ "$cur" == -* ]]; then
+        _app_complete_reply "$cur" '--env' '-e' '--level' '--workers' '--config' '--dry' '-d'
+    fi
+}
+
+complete -F _app_complete 'app'
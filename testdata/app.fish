@@ -0,0 +1,6 @@
+# fish completion for app
+complete -c 'app' -l 'env' -s 'e' -x -a '(\'app\' __complete (commandline -opc)[2..-1] (commandline -ct))' -d 'Environment to deploy to.'
+complete -c 'app' -l 'level' -x -a '\'\\\'debug\\\'\' \'\\\'info\\\'\'' -d 'Log level.'
+complete -c 'app' -l 'workers' -x -a '\'1\' \'2\' \'4\''
+complete -c 'app' -l 'config' -r -F -d 'Config file.'
+complete -c 'app' -l 'dry' -s 'd' -d 'Don\'t change anything.'
@@ -3,7 +3,8 @@ package flags
 import (
 	"reflect"
 	"slices"
-	"time"
+
+	"github.com/vandi37/flags/internal/convert"
 )
 
 // it appends a new time format string to the list of accepted time formats.
@@ -12,7 +13,7 @@ import (
 // formats.  This allows the application to recognize additional, user-defined
 // or custom time formats.
 func AddTimeFormat(format string) {
-	timeFormats = append(timeFormats, format)
+	convert.TimeFormats = append(convert.TimeFormats, format)
 }
 
 // it returns a copy of the current list of accepted time format strings.
@@ -22,53 +23,23 @@ func AddTimeFormat(format string) {
 // internally. This ensures that the internal state is protected from accidental
 // or intentional changes from external code.
 func GetTimeFormats() []string {
-	return slices.Clone(timeFormats)
-}
-
-// TODO: Adding removing or other better working with time formats, cause now it is bad(
-var timeFormats = []string{
-	time.Layout,
-	time.ANSIC,
-	time.UnixDate,
-	time.RubyDate,
-	time.RFC822,
-	time.RFC822Z,
-	time.RFC850,
-	time.RFC1123,
-	time.RFC1123Z,
-	time.RFC3339,
-	time.RFC3339Nano,
-	time.Kitchen,
-	time.Stamp,
-	time.StampMilli,
-	time.StampMicro,
-	time.StampNano,
-	time.DateTime,
-	time.DateOnly,
-	time.TimeOnly,
+	return slices.Clone(convert.TimeFormats)
 }
 
-func parseTime(s string) (time.Time, bool) {
-	if c, ok := convertString(s); ok {
-		s = c
-	}
-	for _, format := range timeFormats {
-		if t, err := time.Parse(format, s); err == nil {
-			return t, true
-		}
-	}
-	return time.Time{}, false
-}
+var (
+	parseTime      = convert.Time
+	convertTimeArg = convert.TimeArg
+)
 
 func setTime(val reflect.Value, args []string, fieldName string) error {
 	if len(args) != 1 {
-		return TOO_MANY_ARGUMENTS(fieldName)
+		return arityError(fieldName, len(args), 1)
 	}
 
-	if t, ok := parseTime(args[0]); ok {
-		val.Set(reflect.ValueOf(t))
+	if t, err := convertTimeArg(args[0]); err != nil {
+		return err
 	} else {
-		return CANT_CONVERT(args[0], "time.Time")
+		val.Set(reflect.ValueOf(t))
 	}
 
 	return nil
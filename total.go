@@ -17,7 +17,8 @@ import (
 // [LoadWithShortcuts].
 //
 // It returns an error if there is an issue during argument parsing or
-// struct population.
+// struct population. It returns the [HELP] error, if help is requested with
+// "--help" (see [Parser.Load]).
 //
 // Full flag forming rules amd flag values parsing rules are in readme
 func Load(args []string, v any) error {
@@ -40,11 +41,79 @@ func Load(args []string, v any) error {
 //
 // Full flag forming rules amd flag values parsing rules are in readme
 func LoadWithShortcuts(args []string, v any, shortcuts map[rune]string) error {
-	if f, err := ParseWithShortcuts(args, shortcuts); err != nil {
-		return err
-	} else {
-		return Insert(f, v)
+	p := &Parser{Shortcuts: shortcuts}
+	return p.Load(args, v)
+}
+
+// it parses command-line arguments and loads the results into a struct
+// using the parser settings.
+//
+// It works the same way as [LoadWithShortcuts] with [Parser.Shortcuts], but
+// inserts the results with [Parser.Insert]. Shortcuts and aliases are also
+// taken from tags of the struct (see [TagShortcuts] and [TagAliases]),
+// [Parser.Shortcuts] and [Parser.Aliases] override them.
+//
+// If [Parser.ConfigFlag] is set and given in the arguments, values from the
+// config file are used for flags, that aren't in the arguments. So values are
+// taken from command-line flags, then environment variables, then the config file.
+//
+// If the arguments have "--help" or "-h" (and the struct doesn't use them), it
+// returns the [HELP] error, so help text could be printed (see [Usage]).
+//
+// If the first argument is "__complete", it answers a completion query from a
+// completion script (see [Parser.WriteBashCompletion]): it writes values for
+// the last argument to [Parser.Output] and returns the [COMPLETE] error.
+func (p *Parser) Load(args []string, v any) error {
+	_, err := p.LoadReport(args, v)
+	return err
+}
+
+// it works the same way as [Load], but also returns a report with the
+// source of every field, so fields set by the user could be told from fields
+// left with their default values (see [Report.IsSet]).
+func LoadReport(args []string, v any) (*Report, error) {
+	return new(Parser).LoadReport(args, v)
+}
+
+// it works the same way as [Parser.Load], but also returns a report with the
+// source of every field (see [Report]).
+//
+// Fields from the arguments have the source "arguments", fields from the
+// config file have the path of the file as the source.
+func (p *Parser) LoadReport(args []string, v any) (*Report, error) {
+	p, err := p.withTags(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := p.completeArgs(args, v); ok {
+		return nil, err
 	}
+	if p.helpRequested(args, v) {
+		return nil, HELP()
+	}
+
+	f, pos, err := p.parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.insertParsed(f, pos, v)
+}
+
+// it inserts parsed flags and values from the config file into a struct.
+func (p *Parser) insertParsed(f map[string][]string, pos positions, v any) (*Report, error) {
+	sources := []Source{}
+	conf, err := p.config(f, v)
+	if err != nil {
+		return nil, err
+	}
+	if conf != nil {
+		sources = append(sources, *conf)
+	}
+	sources = append(sources, Source{Name: argumentsSource, Flags: f, OverrideEnv: true})
+
+	return p.insertSources(pos, v, sources...)
 }
 
 // ir parses command-line arguments (from [os.Args]) and loads the results
@@ -81,3 +150,11 @@ func Args(v any) error {
 func ArgsWithShortcuts(v any, shortcuts map[rune]string) error {
 	return LoadWithShortcuts(os.Args[1:], v, shortcuts)
 }
+
+// it parses command-line arguments (from `os.Args`) and loads the results
+// into a struct using the parser settings.
+//
+// It is similar to [Parser.Load] but it uses `os.Args[1:]`.
+func (p *Parser) Args(v any) error {
+	return p.Load(os.Args[1:], v)
+}
@@ -0,0 +1,141 @@
+package flags
+
+import (
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/vandi37/flags/internal/convert"
+	"github.com/vandi37/flags/internal/usage"
+)
+
+// it returns help text for the flags of a struct.
+//
+// It goes through the fields the same way as [Insert] and prints every flag
+// with its shortcut, type, default value (the current non zero value of the
+// field), the `usage:"<description>"` tag, the environment variable, the
+// allowed values and the required marker. Fields of nested structs are
+// grouped by the struct.
+//
+// Example:
+//
+//	Flags:
+//	  -p, --port int       Port to listen on. (default 3700) (env APP_PORT)
+//	      --level string   Log level. (one of: debug, info) (required)
+//
+// Descriptions are wrapped to the width of the terminal (the COLUMNS
+// environment variable), or to 80 characters.
+//
+// It panics if v isn't a struct or a pointer to a struct, or if its tags
+// are wrong (e.g. conflicting shortcuts), use [WriteUsage] to get the error.
+func Usage(v any) string {
+	return new(Parser).Usage(v)
+}
+
+// it writes help text for the flags of a struct (see [Usage]).
+func WriteUsage(w io.Writer, v any) error {
+	return new(Parser).WriteUsage(w, v)
+}
+
+// it returns help text for the flags of a struct using the parser settings.
+//
+// It works the same way as [Usage], but shortcuts are taken from
+// [Parser.Shortcuts], environment variables from [Parser.EnvPrefix] and the
+// width from [Parser.Width]. It panics on the errors of [Parser.WriteUsage].
+func (p *Parser) Usage(v any) string {
+	var b strings.Builder
+	if err := p.WriteUsage(&b, v); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// it writes help text for the flags of a struct using the parser settings
+// (see [Parser.Usage]).
+func (p *Parser) WriteUsage(w io.Writer, v any) error {
+	docs, err := p.flagDocs(v)
+	if err != nil {
+		return err
+	}
+	return p.writeUsage(w, docs)
+}
+
+// it describes a flag for help text without reflection, it is used by code
+// generated with the flagsgen command (see [Parser.FlagUsage]).
+//
+// Fields: Name (the full flag name), Group (the path of the nested struct),
+// Short (the shortcut or zero), Aliases (other full names), Type (e.g. "int",
+// "duration" or "string..."), Default (the default value as it is written in
+// the command line), Usage (the `usage` tag), Env (the `env` tag), Enum (the
+// values from the `enum` tag) and Required.
+type FlagInfo = usage.Flag
+
+// it returns help text for the flags the same way as [Parser.Usage].
+//
+// Shortcuts from [Parser.Shortcuts] and environment variables from
+// [Parser.EnvPrefix] are used for flags without them.
+func (p *Parser) FlagUsage(flags []FlagInfo) string {
+	infos := []FlagInfo{}
+	for _, info := range flags {
+		if s, ok := p.shortcut(info.Name); ok && info.Short == 0 {
+			info.Short = s
+		}
+		info.Env = convert.EnvVariable(info.Env, p.EnvPrefix, info.Name)
+		infos = append(infos, info)
+	}
+
+	var b strings.Builder
+	usage.Write(&b, infos, p.width())
+	return b.String()
+}
+
+func (p *Parser) writeUsage(w io.Writer, docs []flagDoc) error {
+	infos := []FlagInfo{}
+	for _, doc := range docs {
+		infos = append(infos, doc.info())
+	}
+	return usage.Write(w, infos, p.width())
+}
+
+// it reports whether help is requested with "--help" or "-h", if the struct
+// doesn't use them.
+func (p *Parser) helpRequested(args []string, v any) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--help":
+			if !p.hasFlag(v, "help") {
+				return true
+			}
+		case "-h":
+			if _, ok := p.Shortcuts['h']; !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// it reports whether a field of the struct is bound to the flag.
+func (p *Parser) hasFlag(v any, name string) bool {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return false
+	}
+	for _, field := range p.flagFields(t) {
+		if field.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// it returns the width of the terminal.
+func (p *Parser) width() int {
+	return usage.Width(p.Width, p.lookupEnv)
+}
+
+// The column layout is shared with generated help text (see [usage]).
+var (
+	writeColumns = usage.Columns
+	wrap         = usage.Wrap
+)
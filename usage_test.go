@@ -0,0 +1,87 @@
+package flags_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vandi37/flags"
+)
+
+type withUsage struct {
+	Port    int    `usage:"Port to listen on." env:"APP_PORT"`
+	Level   string `usage:"Log level." enum:"debug,info" required:"true"`
+	Verbose bool   `usage:"Print more information about every request, response and error, that happens while the server is running."`
+	Timeout time.Duration
+	Tags    []string
+	Db      struct {
+		Host string `usage:"Database host."`
+	} `prefix:"db"`
+}
+
+func TestUsage(t *testing.T) {
+	val := &withUsage{Port: 3700, Timeout: time.Second, Tags: []string{"a", "b"}}
+	p := &flags.Parser{Shortcuts: map[rune]string{'p': "port", 'v': "verbose"}, Width: 80}
+
+	need := strings.Join([]string{
+		"Flags:",
+		"  -p, --port int           Port to listen on. (default 3700) (env APP_PORT)",
+		"      --level string       Log level. (one of: debug, info) (required)",
+		"  -v, --verbose            Print more information about every request, response",
+		"                           and error, that happens while the server is running.",
+		"      --timeout duration   (default 1s)",
+		"      --tags string...     (default 'a' 'b')",
+		"",
+		"Db:",
+		"      --db.host string     Database host.",
+		"",
+	}, "\n")
+
+	if res := p.Usage(val); res != need {
+		t.Fatalf("got usage:\n%s\nexpected:\n%s", res, need)
+	}
+}
+
+func TestUsageError(t *testing.T) {
+	if err := flags.WriteUsage(new(strings.Builder), new(int)); !errors.Is(err, flags.IS_NOT_A_STRUCT()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.IS_NOT_A_STRUCT(), err)
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, flags.IS_NOT_A_STRUCT()) {
+			t.Fatalf("got different panics expected %v, got %v", flags.IS_NOT_A_STRUCT(), err)
+		}
+	}()
+	flags.Usage(new(int))
+}
+
+func TestHelp(t *testing.T) {
+	for _, args := range [][]string{{"--help"}, {"--port", "1", "-h"}} {
+		if err := flags.Load(args, new(Cfg)); !errors.Is(err, flags.HELP()) {
+			t.Fatalf("got different errors expected %v, got %v", flags.HELP(), err)
+		}
+	}
+
+	if err := flags.LoadWithShortcuts([]string{"-h", "'localhost'"}, new(Cfg), map[rune]string{'h': "host"}); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+}
+
+func TestRequiredAndEnum(t *testing.T) {
+	// The tags are checked only with [flags.Parser.Validate].
+	if err := flags.Load(strings.Fields("--level 'warn'"), new(withUsage)); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+
+	p := &flags.Parser{Validate: true}
+	if err := p.Load(nil, new(withUsage)); !errors.Is(err, flags.REQUIRED_FLAG()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.REQUIRED_FLAG(), err)
+	}
+	if err := p.Load(strings.Fields("--level 'warn'"), new(withUsage)); !errors.Is(err, flags.NOT_ALLOWED()) {
+		t.Fatalf("got different errors expected %v, got %v", flags.NOT_ALLOWED(), err)
+	}
+	if err := p.Load(strings.Fields("--level 'info'"), new(withUsage)); err != nil {
+		t.Fatalf("got an error: %v", err)
+	}
+}